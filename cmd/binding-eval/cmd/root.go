@@ -80,7 +80,7 @@ func evalBinding(w io.Writer, bindingPath, httpPath string) error {
 		BindingParams: bindingParams,
 	}
 
-	params, err := template.ResolveParams(t, body, r.Header, map[string]interface{}{})
+	params, err := template.ResolveParams(t, body, r.Header, map[string]interface{}{}, template.EventContext{})
 	if err != nil {
 		return fmt.Errorf("error resolving params: %w", err)
 	}
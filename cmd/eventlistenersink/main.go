@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"github.com/tektoncd/triggers/pkg/adapter"
 	dynamicClientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
@@ -28,6 +29,7 @@ import (
 	evadapter "knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/metrics"
 	"knative.dev/pkg/signals"
 )
 
@@ -57,7 +59,11 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	recorder, err := sink.NewRecorder()
+	metricsConfig, err := getMetricsConfig()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	recorder, err := sink.NewRecorderWithConfig(metricsConfig)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -68,3 +74,13 @@ func main() {
 
 	evadapter.MainWithContext(ctx, EventListenerLogKey, adapter.NewEnvConfig, adapter.New(sinkArgs, sinkClients, recorder))
 }
+
+// getMetricsConfig returns the metrics configuration in the config-observability-triggers
+// ConfigMap, which the reconciler passes on to the EventListener in K_METRICS_CONFIG.
+func getMetricsConfig() (*sink.MetricsConfig, error) {
+	var data map[string]string
+	if opts, err := metrics.JSONToOptions(os.Getenv(evadapter.EnvConfigMetricsConfig)); err == nil {
+		data = opts.ConfigMap
+	}
+	return sink.NewMetricsConfig(data)
+}
@@ -36,6 +36,7 @@ import (
 	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
 	dynamicClientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
 	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	"github.com/tektoncd/triggers/pkg/resources"
 	"github.com/tektoncd/triggers/pkg/sink"
 	"github.com/tektoncd/triggers/pkg/template"
 	"go.uber.org/zap"
@@ -128,7 +129,7 @@ func trigger(triggerFile, httpPath, action, kubeconfig string, writer io.Writer)
 			}
 		case "create":
 			{
-				err := r.CreateResources(tri.Namespace, "", resources, tri.Name, eventID, eventLog)
+				_, err := r.CreateResources(context.Background(), tri.Namespace, "", resources, tri.Name, eventID, eventLog, nil)
 				if err != nil {
 					return fmt.Errorf("fail to create resources: %w", err)
 				}
@@ -229,7 +230,7 @@ func processTriggerSpec(kubeClient kubernetes.Interface, client triggersclientse
 	if iresp != nil && iresp.Extensions != nil {
 		extensions = iresp.Extensions
 	}
-	params, err := template.ResolveParams(rt, finalPayload, header, extensions)
+	params, err := template.ResolveParams(rt, finalPayload, header, extensions, template.EventContext{})
 	if err != nil {
 		log.Error("Failed to resolve parameters", err)
 		return nil, err
@@ -263,7 +264,7 @@ func newSink(config *rest.Config, sugerLogger *zap.SugaredLogger) sink.Sink {
 		HTTPClient:             http.DefaultClient,
 		Auth:                   sink.DefaultAuthOverride{},
 		WGProcessTriggers:      &sync.WaitGroup{},
-		DiscoveryClient:        sinkClients.DiscoveryClient,
+		RESTMapper:             resources.NewRESTMapper(sinkClients.DiscoveryClient),
 		DynamicClient:          dynamicCS,
 		Logger:                 sugerLogger,
 		EventListenerNamespace: "default",
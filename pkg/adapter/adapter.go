@@ -18,8 +18,11 @@ package adapter
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
 	"sync"
 	"time"
 
@@ -31,16 +34,33 @@ import (
 	triggertemplatesinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1beta1/triggertemplate"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/tektoncd/triggers/pkg/resources"
 	"github.com/tektoncd/triggers/pkg/sink"
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/adapter/v2"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 )
 
+// certificateReloadInterval is how often the TLS certificate files are checked for changes.
+const certificateReloadInterval = 30 * time.Second
+
+// concurrencyReleaseInterval is how often queued PipelineRuns are checked for whether
+// the runs before them completed.
+const concurrencyReleaseInterval = 10 * time.Second
+
+// maxDeadLettersInFlight is the number of dead letters that are sent at once.
+const maxDeadLettersInFlight = 16
+
 type envConfig struct {
 	adapter.EnvConfig
+
+	// DryRunToken is the token that authorizes dry run requests, if the
+	// EventListener authorizes them with a token.
+	DryRunToken string `envconfig:"DRY_RUN_TOKEN"`
 }
 
 func NewEnvConfig() adapter.EnvConfigAccessor {
@@ -55,6 +75,10 @@ type sinker struct {
 	Args     sink.Args
 	Clients  sink.Clients
 	Recorder *sink.Recorder
+	// ceClient sends the lifecycle CloudEvents of the EventListener.
+	ceClient cloudevents.Client
+	// dryRunToken authorizes dry run requests.
+	dryRunToken string
 
 	injCtx context.Context
 }
@@ -62,20 +86,51 @@ type sinker struct {
 var _ adapter.Adapter = (*sinker)(nil)
 
 func (s *sinker) Start(ctx context.Context) error {
+	// Without a collector the global tracer provider is left as is, so spans are not
+	// recorded and created resources are not annotated with trace IDs.
+	if s.Args.TracingCollectorAddress != "" {
+		tp, err := sink.NewTracerProvider(ctx, s.Args.TracingCollectorAddress, s.Args.ElName)
+		if err != nil {
+			return fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+		otel.SetTracerProvider(tp)
+		defer func() {
+			// The context of the adapter is done by now, so the remaining spans are
+			// flushed with a context of their own.
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := tp.Shutdown(ctx); err != nil {
+				s.Logger.Errorf("Failed to flush traces: %v", err)
+			}
+		}()
+	}
+
+	authOverride := sink.DefaultAuthOverride{}
+	if s.Args.ClientCacheTTL > 0 {
+		authOverride = sink.NewDefaultAuthOverride(s.Args.ClientCacheTTL*time.Second, s.Recorder)
+	}
+
 	// Create EventListener Sink
 	r := sink.Sink{
 		KubeClientSet:          kubeclient.Get(ctx),
-		DiscoveryClient:        s.Clients.DiscoveryClient,
+		RESTMapper:             resources.NewRESTMapper(s.Clients.DiscoveryClient),
 		DynamicClient:          dynamicclient.Get(ctx),
 		TriggersClient:         s.Clients.TriggersClient,
 		HTTPClient:             http.DefaultClient,
 		EventListenerName:      s.Args.ElName,
 		EventListenerNamespace: s.Args.ElNamespace,
 		PayloadValidation:      s.Args.PayloadValidation,
+		MaxBodySize:            s.Args.MaxBodySize,
+		AllowedContentTypes:    s.Args.AllowedContentTypes,
+		AllowedSourceRanges:    s.Args.AllowedSourceRanges,
+		TrustedProxies:         s.Args.TrustedProxies,
 		Logger:                 s.Logger,
 		Recorder:               s.Recorder,
-		Auth:                   sink.DefaultAuthOverride{},
+		Auth:                   authOverride,
 		WGProcessTriggers:      &sync.WaitGroup{},
+		InFlight:               sink.NewInFlightEvents(),
+		CloudEventClient:       s.ceClient,
+		DryRunToken:            s.dryRunToken,
 
 		// Register all the listers we'll need
 		EventListenerLister:         eventlistenerinformer.Get(s.injCtx).Lister(),
@@ -86,18 +141,60 @@ func (s *sinker) Start(ctx context.Context) error {
 		ClusterInterceptorLister:    clusterinterceptorsinformer.Get(s.injCtx).Lister(),
 	}
 
+	eventRecorder, stopEvents := sink.NewEventRecorder(r.KubeClientSet, r.EventListenerName)
+	defer stopEvents()
+	r.EventRecorder = eventRecorder
+
+	r.Deduplicator = sink.NewDeduplicator(r.KubeClientSet, r.EventListenerName, r.EventListenerNamespace, s.Logger)
+	go r.Deduplicator.Start(ctx, time.Minute)
+	r.ConcurrencyGroups = sink.NewConcurrencyGroups(s.Logger)
+	go r.StartQueuedRuns(ctx, concurrencyReleaseInterval)
+	go r.StartTriggerInvocationGC(ctx, time.Minute)
+	go r.StartResourceRetention(ctx, time.Minute)
+
+	r.DeadLetterSender = sink.NewDeadLetterSender(maxDeadLettersInFlight)
+
+	if s.Args.MaxWorkers > 0 {
+		r.WorkerPool = sink.NewWorkerPool(s.Args.MaxWorkers, s.Args.MaxQueueDepth)
+		r.WorkerPool.Start()
+	}
+
 	mux := http.NewServeMux()
 	eventHandler := http.HandlerFunc(r.HandleEvent)
-	metricsRecorder := &sink.MetricsHandler{Handler: r.IsValidPayload(eventHandler)}
+	metricsRecorder := &sink.MetricsHandler{Handler: r.EnforceSourceIPPolicy(r.EnforcePayloadPolicy(r.IsValidPayload(eventHandler)))}
 
 	mux.HandleFunc("/", http.HandlerFunc(metricsRecorder.Intercept(r.NewMetricsRecorderInterceptor())))
 
-	// For handling Liveness Probe
-	// TODO(dibyom): Livness, metrics etc. should be on a separate port
-	mux.HandleFunc("/live", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(200)
-		fmt.Fprint(w, "ok")
-	})
+	synced := []cache.InformerSynced{
+		eventlistenerinformer.Get(s.injCtx).Informer().HasSynced,
+		triggersinformer.Get(s.injCtx).Informer().HasSynced,
+		triggerbindingsinformer.Get(s.injCtx).Informer().HasSynced,
+		clustertriggerbindingsinformer.Get(s.injCtx).Informer().HasSynced,
+		triggertemplatesinformer.Get(s.injCtx).Informer().HasSynced,
+		clusterinterceptorsinformer.Get(s.injCtx).Informer().HasSynced,
+	}
+	errCh := make(chan error, 2)
+	var adminSrv *http.Server
+	if s.Args.AdminPort == "" {
+		// Without an admin port, the probes are served along with events.
+		mux.HandleFunc("/live", sink.Live)
+		mux.Handle("/ready", r.ReadyHandler(synced...))
+	} else {
+		adminOpts := sink.AdminOptions{
+			Synced:      synced,
+			EnablePprof: s.Args.EnablePprof,
+		}
+		if port := os.Getenv("METRICS_PROMETHEUS_PORT"); port != "" {
+			adminOpts.MetricsURL = &url.URL{Scheme: "http", Host: "localhost:" + port}
+		}
+		adminSrv = &http.Server{
+			Addr:    fmt.Sprintf(":%s", s.Args.AdminPort),
+			Handler: r.NewAdminHandler(adminOpts),
+		}
+		go func() {
+			errCh <- fmt.Errorf("admin server failed: %w", adminSrv.ListenAndServe())
+		}()
+	}
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", s.Args.Port),
@@ -108,14 +205,59 @@ func (s *sinker) Start(ctx context.Context) error {
 			s.Args.ELTimeOutHandler*time.Second, "EventListener Timeout!\n"),
 	}
 
-	if s.Args.Cert == "" && s.Args.Key == "" {
-		if err := srv.ListenAndServe(); err != nil {
-			return err
+	servesTLS := s.Args.Cert != "" || s.Args.Key != ""
+	if servesTLS {
+		tlsConfig := &tls.Config{}
+		if s.Args.ClientCA != "" {
+			clientAuthConfig, err := sink.ClientAuthTLSConfig(s.Args.ClientCA, s.Args.ClientAuth)
+			if err != nil {
+				return fmt.Errorf("failed to configure client authentication: %w", err)
+			}
+			tlsConfig = clientAuthConfig
 		}
-	} else {
-		if err := srv.ListenAndServeTLS(s.Args.Cert, s.Args.Key); err != nil {
+		// The certificate is served through GetCertificate, so that it can be rotated
+		// without restarting the EventListener.
+		reloader, err := sink.NewCertificateReloader(s.Args.Cert, s.Args.Key, s.Logger, s.Recorder)
+		if err != nil {
 			return err
 		}
+		go reloader.Start(ctx, certificateReloadInterval)
+		tlsConfig.GetCertificate = reloader.GetCertificate
+		srv.TLSConfig = tlsConfig
+	}
+
+	go func() {
+		if !servesTLS {
+			errCh <- srv.ListenAndServe()
+		} else {
+			errCh <- srv.ListenAndServeTLS("", "")
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Stop accepting events, and give the triggers being processed until the end of the
+	// grace period to finish. The admin server keeps serving probes in the meantime.
+	s.Logger.Infof("Shutting down, waiting up to %s for triggers being processed", s.Args.ShutdownGracePeriod*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.Args.ShutdownGracePeriod*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		s.Logger.Errorf("Failed to shut down the EventListener server: %v", err)
+	}
+	if r.WorkerPool != nil {
+		r.WorkerPool.Close()
+	}
+	if err := r.Drain(shutdownCtx); err != nil {
+		s.Logger.Errorf("Shutdown grace period expired before all triggers were processed: %v", err)
+	}
+	if adminSrv != nil {
+		if err := adminSrv.Close(); err != nil {
+			s.Logger.Errorf("Failed to close the admin server: %v", err)
+		}
 	}
 	return nil
 }
@@ -126,12 +268,14 @@ func New(sinkArgs sink.Args, sinkClients sink.Clients, recorder *sink.Recorder)
 		logger := logging.FromContext(ctx)
 
 		return &sinker{
-			Logger:    logger,
-			Namespace: env.Namespace,
-			Args:      sinkArgs,
-			Clients:   sinkClients,
-			Recorder:  recorder,
-			injCtx:    ctx,
+			Logger:      logger,
+			Namespace:   env.Namespace,
+			Args:        sinkArgs,
+			Clients:     sinkClients,
+			Recorder:    recorder,
+			ceClient:    ceClient,
+			dryRunToken: env.DryRunToken,
+			injCtx:      ctx,
 		}
 	}
 }
@@ -15,4 +15,32 @@ const (
 
 	// TriggerGroupLabelKey is used as a label identifier for a TriggerGroup
 	TriggerGroupLabelKey = "/triggergroup"
+
+	// TriggerNamespaceLabelKey is used as the label identifier for the namespace of a Trigger
+	TriggerNamespaceLabelKey = "/trigger-namespace"
+
+	// CreatedByEventLabelKey labels the resources that were created for a single event,
+	// rather than written over a resource that might already exist.
+	CreatedByEventLabelKey = "/created-by-event"
+
+	// TraceIDAnnotation is the annotation that records the ID of the trace of the event
+	// a resource was created for.
+	TraceIDAnnotation = GroupName + "/trace-id"
+
+	// CreatePolicyAnnotation is the annotation of a resource template that selects how
+	// its resource is created, one of the v1beta1 CreatePolicy values.
+	CreatePolicyAnnotation = GroupName + "/create-policy"
+
+	// ResourceNameAnnotation is the annotation of a resource template that names it, so
+	// that the other resource templates of its TriggerTemplate can refer to it.
+	ResourceNameAnnotation = GroupName + "/resource-name"
+
+	// DependsOnAnnotation is the annotation of a resource template that lists the names
+	// or indexes of the resource templates whose resources are created before its resource,
+	// separated by commas.
+	DependsOnAnnotation = GroupName + "/depends-on"
+
+	// WaitForReadyAnnotation is the annotation of a resource template that, when "true",
+	// waits for its resource to be ready before the next resources are created.
+	WaitForReadyAnnotation = GroupName + "/wait-for-ready"
 )
@@ -50,6 +50,10 @@ type ClusterInterceptor struct {
 // ClusterInterceptorSpec describes the Spec for an ClusterInterceptor
 type ClusterInterceptorSpec struct {
 	ClientConfig ClientConfig `json:"clientConfig"`
+	// Timeout bounds the time spent on each request to the interceptor.
+	// Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ClusterInterceptorStatus holds the status of the ClusterInterceptor
@@ -42,5 +42,8 @@ func (s *ClusterInterceptorSpec) validate(ctx context.Context) (errs *apis.Field
 			errs = errs.Also(apis.ErrMissingField("spec.clientConfig.service.name"))
 		}
 	}
+	if s.Timeout != nil && s.Timeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(s.Timeout.Duration.String(), "spec.timeout"))
+	}
 	return errs
 }
@@ -3,6 +3,7 @@ package v1alpha1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -89,6 +90,23 @@ func TestClusterInterceptorValidate(t *testing.T) {
 			},
 		},
 		want: apis.ErrMissingField("spec.clientConfig.service.name"),
+	}, {
+		name: "negative timeout",
+		clusterInterceptor: triggersv1.ClusterInterceptor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "github",
+			},
+			Spec: triggersv1.ClusterInterceptorSpec{
+				ClientConfig: triggersv1.ClientConfig{
+					Service: &triggersv1.ServiceReference{
+						Namespace: "default",
+						Name:      "github-svc",
+					},
+				},
+				Timeout: &metav1.Duration{Duration: -time.Second},
+			},
+		},
+		want: apis.ErrInvalidValue("-1s", "spec.timeout"),
 	}}
 
 	for _, tc := range tests {
@@ -46,6 +46,12 @@ type TriggerContext struct {
 	EventID string `json:"event_id,omitempty"`
 	// TriggerID is of the form namespace/$ns/triggers/$name
 	TriggerID string `json:"trigger_id,omitempty"`
+	// ClientSubject is the subject of the verified certificate that the client
+	// sent the event with, if the EventListener authenticates its clients
+	ClientSubject string `json:"client_subject,omitempty"`
+	// ClientIP is the IP of the client that sent the event. If the event was sent
+	// through a trusted proxy, it is read from the X-Forwarded-For header
+	ClientIP string `json:"client_ip,omitempty"`
 }
 
 // Do not generate Deepcopy(). See #827
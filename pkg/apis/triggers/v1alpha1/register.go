@@ -58,6 +58,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TriggerTemplateList{},
 		&Trigger{},
 		&TriggerList{},
+		&TriggerInvocation{},
+		&TriggerInvocationList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
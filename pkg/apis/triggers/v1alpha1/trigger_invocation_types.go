@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// TriggerInvocation records how an EventListener processed an event for a
+// single Trigger, or for a TriggerGroup whose interceptors stopped processing
+// the event. TriggerInvocations are written by the EventListener and are not
+// meant to be created by users.
+type TriggerInvocation struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TriggerInvocationSpec `json:"spec"`
+	// +optional
+	Status TriggerInvocationStatus `json:"status,omitempty"`
+}
+
+// TriggerInvocationSpec identifies the event and the Trigger that a TriggerInvocation records.
+type TriggerInvocationSpec struct {
+	// EventID is the ID the EventListener assigned to the event.
+	EventID string `json:"eventID"`
+	// EventListener is the name of the EventListener that processed the event.
+	EventListener string `json:"eventListener"`
+	// Trigger is the name of the Trigger the event was processed for, if any.
+	// +optional
+	Trigger string `json:"trigger,omitempty"`
+	// TriggerNamespace is the namespace of the Trigger.
+	// +optional
+	TriggerNamespace string `json:"triggerNamespace,omitempty"`
+	// TriggerGroup is the name of the TriggerGroup the Trigger was selected by, if any.
+	// +optional
+	TriggerGroup string `json:"triggerGroup,omitempty"`
+}
+
+// TriggerInvocationStatus records the outcome of processing the event.
+type TriggerInvocationStatus struct {
+	// Interceptors are the results of the interceptors that processed the event,
+	// in the order they were called. The interceptors of a TriggerGroup come
+	// before those of the Trigger.
+	// +optional
+	Interceptors []InterceptorInvocation `json:"interceptors,omitempty"`
+	// Params are the params resolved for the TriggerTemplate of the Trigger.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+	// Resources are the resources created from the TriggerTemplate of the Trigger.
+	// +optional
+	Resources []InvocationResource `json:"resources,omitempty"`
+	// ErrorMessage is the error that stopped the event from being processed, if any.
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// StartTime is the time the EventListener started processing the event.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the EventListener finished processing the event.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// InterceptorInvocation is the result of calling a single interceptor.
+type InterceptorInvocation struct {
+	// Name identifies the interceptor, either by the name of the ClusterInterceptor
+	// or by the name of the interceptor configuration.
+	Name string `json:"name"`
+	// Continue is false if the interceptor stopped processing the event.
+	Continue bool `json:"continue"`
+	// Status is the status returned by the interceptor if it stopped processing the event.
+	// +optional
+	Status *Status `json:"status,omitempty"`
+	// ErrorMessage is the error calling the interceptor failed with, if any.
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Duration is how long the interceptor took, including retries.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// InvocationResource identifies a resource created from a TriggerTemplate.
+type InvocationResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// +optional
+	UID string `json:"uid,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// TriggerInvocationList contains a list of TriggerInvocations.
+type TriggerInvocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TriggerInvocation `json:"items"`
+}
@@ -182,6 +182,11 @@ func (in *ClusterInterceptorList) DeepCopyObject() runtime.Object {
 func (in *ClusterInterceptorSpec) DeepCopyInto(out *ClusterInterceptorSpec) {
 	*out = *in
 	in.ClientConfig.DeepCopyInto(&out.ClientConfig)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -512,6 +517,28 @@ func (in *GitLabInterceptor) DeepCopy() *GitLabInterceptor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorInvocation) DeepCopyInto(out *InterceptorInvocation) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(Status)
+		**out = **in
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorInvocation.
+func (in *InterceptorInvocation) DeepCopy() *InterceptorInvocation {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorInvocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterceptorParams) DeepCopyInto(out *InterceptorParams) {
 	*out = *in
@@ -545,6 +572,22 @@ func (in *InterceptorRef) DeepCopy() *InterceptorRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvocationResource) DeepCopyInto(out *InvocationResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvocationResource.
+func (in *InvocationResource) DeepCopy() *InvocationResource {
+	if in == nil {
+		return nil
+	}
+	out := new(InvocationResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesResource) DeepCopyInto(out *KubernetesResource) {
 	*out = *in
@@ -916,6 +959,124 @@ func (in *TriggerInterceptor) DeepCopy() *TriggerInterceptor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerInvocation) DeepCopyInto(out *TriggerInvocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerInvocation.
+func (in *TriggerInvocation) DeepCopy() *TriggerInvocation {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerInvocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerInvocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerInvocationList) DeepCopyInto(out *TriggerInvocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TriggerInvocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerInvocationList.
+func (in *TriggerInvocationList) DeepCopy() *TriggerInvocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerInvocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerInvocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerInvocationSpec) DeepCopyInto(out *TriggerInvocationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerInvocationSpec.
+func (in *TriggerInvocationSpec) DeepCopy() *TriggerInvocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerInvocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerInvocationStatus) DeepCopyInto(out *TriggerInvocationStatus) {
+	*out = *in
+	if in.Interceptors != nil {
+		in, out := &in.Interceptors, &out.Interceptors
+		*out = make([]InterceptorInvocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]InvocationResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerInvocationStatus.
+func (in *TriggerInvocationStatus) DeepCopy() *TriggerInvocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerInvocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerList) DeepCopyInto(out *TriggerList) {
 	*out = *in
@@ -64,6 +64,140 @@ type EventListenerSpec struct {
 	NamespaceSelector NamespaceSelector           `json:"namespaceSelector,omitempty"`
 	LabelSelector     *metav1.LabelSelector       `json:"labelSelector,omitempty"`
 	Resources         Resources                   `json:"resources,omitempty"`
+	// RetryPolicy defines how failed ClusterInterceptor calls and resource creation
+	// are retried for all Triggers of the EventListener
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// Retention determines how long the resources created by the Triggers of the
+	// EventListener are kept
+	// +optional
+	Retention *Retention `json:"retention,omitempty"`
+	// Deduplication drops events that were already delivered to the EventListener
+	// +optional
+	Deduplication *Deduplication `json:"deduplication,omitempty"`
+	// CloudEventURI is the URI of a sink that CloudEvents are sent to as the
+	// EventListener processes events
+	// +optional
+	CloudEventURI *apis.URL `json:"cloudEventURI,omitempty"`
+	// TriggerInvocations records how each event is processed for each Trigger
+	// as a TriggerInvocation in the namespace of the EventListener
+	// +optional
+	TriggerInvocations *TriggerInvocationPolicy `json:"triggerInvocations,omitempty"`
+	// DryRun allows authorized requests to process events without creating
+	// any resources
+	// +optional
+	DryRun *DryRun `json:"dryRun,omitempty"`
+	// PayloadPolicy limits the requests that the EventListener accepts
+	// +optional
+	PayloadPolicy *PayloadPolicy `json:"payloadPolicy,omitempty"`
+	// ProcessingTimeout bounds the time spent processing an event for all
+	// Triggers and TriggerGroups of the EventListener
+	// +optional
+	ProcessingTimeout *metav1.Duration `json:"processingTimeout,omitempty"`
+	// ClientAuth requires the clients that send events to authenticate with a
+	// certificate. It requires the EventListener to serve TLS.
+	// +optional
+	ClientAuth *ClientAuth `json:"clientAuth,omitempty"`
+	// SourceIPPolicy limits the IP addresses that the EventListener accepts
+	// requests from
+	// +optional
+	SourceIPPolicy *SourceIPPolicy `json:"sourceIPPolicy,omitempty"`
+}
+
+// SourceIPPolicy defines which IP addresses the EventListener accepts requests
+// from. Requests from other addresses are rejected with a 403 status before
+// their body is read.
+type SourceIPPolicy struct {
+	// AllowedCIDRs are the IP ranges, in CIDR notation, that requests may be
+	// sent from, such as the ranges that a Git provider sends webhooks from.
+	AllowedCIDRs []string `json:"allowedCIDRs"`
+	// TrustedProxies are the IP ranges, in CIDR notation, of the proxies in
+	// front of the EventListener, such as an ingress controller. The client IP
+	// of requests sent through them is read from the X-Forwarded-For header.
+	// +optional
+	TrustedProxies []string `json:"trustedProxies,omitempty"`
+}
+
+// ClientAuth defines how the EventListener verifies the certificates of its clients.
+type ClientAuth struct {
+	// CABundleSecretRef refers to a key of a Secret holding the PEM encoded CA
+	// certificates that client certificates are verified with.
+	CABundleSecretRef *SecretRef `json:"caBundleSecretRef,omitempty"`
+	// Mode is whether clients must present a certificate. Defaults to Required.
+	// +optional
+	Mode ClientAuthMode `json:"mode,omitempty"`
+}
+
+// ClientAuthMode is whether clients of an EventListener must present a certificate.
+type ClientAuthMode string
+
+const (
+	// ClientAuthRequired rejects clients that do not present a valid certificate.
+	ClientAuthRequired ClientAuthMode = "Required"
+	// ClientAuthOptional accepts clients without a certificate, but rejects clients
+	// that present a certificate that cannot be verified.
+	ClientAuthOptional ClientAuthMode = "Optional"
+)
+
+// PayloadPolicy defines which requests the EventListener accepts. Requests
+// that violate the policy are rejected before they are processed.
+type PayloadPolicy struct {
+	// MaxBodySize is the maximum size in bytes of request bodies. Larger
+	// requests are rejected with a 413 status.
+	// +optional
+	MaxBodySize *int64 `json:"maxBodySize,omitempty"`
+	// AllowedContentTypes are the media types that requests may send, such as
+	// application/json. Requests with other content types are rejected with a
+	// 415 status. If empty, requests may send any content type.
+	// +optional
+	AllowedContentTypes []string `json:"allowedContentTypes,omitempty"`
+}
+
+// DryRun defines how requests to process an event without creating resources
+// are authorized. A request must pass every check that is configured.
+type DryRun struct {
+	// TokenSecretRef refers to a key of a Secret holding the token that dry run
+	// requests must send in the Tekton-Triggers-Dry-Run-Token header.
+	// +optional
+	TokenSecretRef *SecretRef `json:"tokenSecretRef,omitempty"`
+	// SubjectAccessReview requires dry run requests to send the bearer token of
+	// a Kubernetes user that can create the eventlisteners/dryrun subresource
+	// of the EventListener.
+	// +optional
+	SubjectAccessReview bool `json:"subjectAccessReview,omitempty"`
+}
+
+// TriggerInvocationPolicy defines how the EventListener records TriggerInvocations.
+type TriggerInvocationPolicy struct {
+	// TTL is how long TriggerInvocations are kept after the event was processed.
+	// Defaults to 24h.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// DeduplicationStore is where the keys of delivered events are recorded.
+type DeduplicationStore string
+
+const (
+	// DeduplicationStoreMemory records keys in the memory of each EventListener replica.
+	DeduplicationStoreMemory DeduplicationStore = "memory"
+	// DeduplicationStoreLease records keys as Leases in the namespace of the EventListener,
+	// so that all replicas of the EventListener agree on which events were delivered.
+	DeduplicationStoreLease DeduplicationStore = "lease"
+)
+
+// Deduplication defines how the EventListener recognizes events that were
+// already delivered, such as webhooks redelivered by a Git provider.
+type Deduplication struct {
+	// Key identifies an event. It is resolved like a TriggerBinding param value,
+	// for example $(header.X-GitHub-Delivery) or $(body.id).
+	Key string `json:"key"`
+	// TTL is how long the key of a delivered event is remembered. Defaults to 1h.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Store is where keys are recorded, either memory or lease. Defaults to memory.
+	// +optional
+	Store DeduplicationStore `json:"store,omitempty"`
 }
 
 type Resources struct {
@@ -112,6 +246,25 @@ type EventListenerTrigger struct {
 	// multi-tenant model based scenarios
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// RetryPolicy overrides the RetryPolicy of the EventListener for this Trigger
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// Timeout bounds the time spent processing an event for this Trigger,
+	// including its interceptors and the creation of its resources
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Path restricts the Trigger to requests with a matching URL path
+	// +optional
+	Path string `json:"path,omitempty"`
+	// PathType determines how Path is matched, either Exact or Prefix. Defaults to Exact.
+	// +optional
+	PathType PathType `json:"pathType,omitempty"`
+	// Concurrency controls the runs created by the Trigger for events with the same key
+	// +optional
+	Concurrency *Concurrency `json:"concurrency,omitempty"`
+	// Retention overrides the Retention of the EventListener for this Trigger
+	// +optional
+	Retention *Retention `json:"retention,omitempty"`
 }
 
 // EventListenerTriggerGroup defines a group of Triggers that share a common set of interceptors
@@ -119,6 +272,12 @@ type EventListenerTriggerGroup struct {
 	Name            string                       `json:"name"`
 	Interceptors    []*TriggerInterceptor        `json:"interceptors"`
 	TriggerSelector EventListenerTriggerSelector `json:"triggerSelector"`
+	// Path restricts the TriggerGroup to requests with a matching URL path
+	// +optional
+	Path string `json:"path,omitempty"`
+	// PathType determines how Path is matched, either Exact or Prefix. Defaults to Exact.
+	// +optional
+	PathType PathType `json:"pathType,omitempty"`
 }
 
 // EventListenerTriggerSelector  defines ways to select a group of triggers using their metadata
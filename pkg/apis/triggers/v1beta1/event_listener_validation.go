@@ -21,6 +21,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"mime"
+	"net"
+	"strings"
 
 	"github.com/tektoncd/triggers/pkg/apis/config"
 
@@ -79,6 +82,77 @@ func (s *EventListenerSpec) validate(ctx context.Context) (errs *apis.FieldError
 		errs = errs.Also(validateCustomObject(s.Resources.CustomResource).ViaField("spec.resources.customResource"))
 	}
 
+	if s.RetryPolicy != nil {
+		errs = errs.Also(s.RetryPolicy.validate(ctx).ViaField("spec.retryPolicy"))
+	}
+
+	if s.Retention != nil {
+		errs = errs.Also(s.Retention.validate(ctx).ViaField("spec.retention"))
+	}
+
+	if s.Deduplication != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.deduplication", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else {
+			errs = errs.Also(s.Deduplication.validate().ViaField("spec.deduplication"))
+		}
+	}
+
+	if s.CloudEventURI != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.cloudEventURI", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else if s.CloudEventURI.Scheme != "http" && s.CloudEventURI.Scheme != "https" || s.CloudEventURI.Host == "" {
+			errs = errs.Also(apis.ErrInvalidValue(s.CloudEventURI.String(), "spec.cloudEventURI"))
+		}
+	}
+
+	if s.TriggerInvocations != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.triggerInvocations", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else if ttl := s.TriggerInvocations.TTL; ttl != nil && ttl.Duration <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(ttl.Duration.String(), "spec.triggerInvocations.ttl"))
+		}
+	}
+
+	if s.DryRun != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.dryRun", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else {
+			errs = errs.Also(s.DryRun.validate().ViaField("spec.dryRun"))
+		}
+	}
+
+	if s.PayloadPolicy != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.payloadPolicy", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else {
+			errs = errs.Also(s.PayloadPolicy.validate().ViaField("spec.payloadPolicy"))
+		}
+	}
+
+	if s.ProcessingTimeout != nil {
+		errs = errs.Also(validateTimeout(ctx, s.ProcessingTimeout, "spec.processingTimeout"))
+	}
+
+	if s.ClientAuth != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.clientAuth", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else {
+			errs = errs.Also(s.ClientAuth.validate().ViaField("spec.clientAuth"))
+			if !servesTLS(s.Resources.KubernetesResource) {
+				errs = errs.Also(apis.ErrGeneric("clientAuth requires the TLS_CERT and TLS_KEY env vars of the EventListener to be set", "spec.clientAuth"))
+			}
+		}
+	}
+
+	if s.SourceIPPolicy != nil {
+		if err := ValidateEnabledAPIFields(ctx, "spec.sourceIPPolicy", config.AlphaAPIFieldValue); err != nil {
+			errs = errs.Also(err)
+		} else {
+			errs = errs.Also(s.SourceIPPolicy.validate().ViaField("spec.sourceIPPolicy"))
+		}
+	}
+
 	if len(s.TriggerGroups) > 0 {
 		err := ValidateEnabledAPIFields(ctx, "spec.triggerGroups", config.AlphaAPIFieldValue)
 		if err != nil {
@@ -99,6 +173,105 @@ func (g *EventListenerTriggerGroup) validate(ctx context.Context) (errs *apis.Fi
 	if len(g.Interceptors) == 0 {
 		errs = errs.Also(apis.ErrMissingField("interceptors"))
 	}
+	errs = errs.Also(validatePath(ctx, g.Path, g.PathType))
+	return errs
+}
+
+func (d *Deduplication) validate() (errs *apis.FieldError) {
+	if d.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	} else if !strings.Contains(d.Key, "$(") {
+		// A key without any expression would be the same for every event.
+		errs = errs.Also(apis.ErrInvalidValue(d.Key, "key"))
+	} else {
+		errs = errs.Also(validateParamValue(d.Key).ViaField("key"))
+	}
+	if d.TTL != nil && d.TTL.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(d.TTL.Duration.String(), "ttl"))
+	}
+	switch d.Store {
+	case "", DeduplicationStoreMemory, DeduplicationStoreLease:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(d.Store, "store"))
+	}
+	return errs
+}
+
+func (d *DryRun) validate() (errs *apis.FieldError) {
+	if d.TokenSecretRef == nil && !d.SubjectAccessReview {
+		return apis.ErrMissingOneOf("tokenSecretRef", "subjectAccessReview")
+	}
+	if ref := d.TokenSecretRef; ref != nil {
+		if ref.SecretName == "" {
+			errs = errs.Also(apis.ErrMissingField("tokenSecretRef.secretName"))
+		}
+		if ref.SecretKey == "" {
+			errs = errs.Also(apis.ErrMissingField("tokenSecretRef.secretKey"))
+		}
+	}
+	return errs
+}
+
+func (c *ClientAuth) validate() (errs *apis.FieldError) {
+	if ref := c.CABundleSecretRef; ref == nil {
+		errs = errs.Also(apis.ErrMissingField("caBundleSecretRef"))
+	} else {
+		if ref.SecretName == "" {
+			errs = errs.Also(apis.ErrMissingField("caBundleSecretRef.secretName"))
+		}
+		if ref.SecretKey == "" {
+			errs = errs.Also(apis.ErrMissingField("caBundleSecretRef.secretKey"))
+		}
+	}
+	switch c.Mode {
+	case "", ClientAuthRequired, ClientAuthOptional:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(c.Mode, "mode"))
+	}
+	return errs
+}
+
+// servesTLS returns true if the container of k sets the certificate and key that the
+// EventListener serves TLS with.
+func servesTLS(k *KubernetesResource) bool {
+	if k == nil {
+		return false
+	}
+	env := map[string]bool{}
+	for _, c := range k.Template.Spec.Containers {
+		for _, e := range c.Env {
+			env[e.Name] = true
+		}
+	}
+	return env["TLS_CERT"] && env["TLS_KEY"]
+}
+
+func (p *PayloadPolicy) validate() (errs *apis.FieldError) {
+	if p.MaxBodySize != nil && *p.MaxBodySize <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*p.MaxBodySize, "maxBodySize"))
+	}
+	for i, ct := range p.AllowedContentTypes {
+		if mediaType, params, err := mime.ParseMediaType(ct); err != nil || len(params) > 0 || !strings.Contains(mediaType, "/") {
+			errs = errs.Also(apis.ErrInvalidArrayValue(ct, "allowedContentTypes", i))
+		}
+	}
+	return errs
+}
+
+func (p *SourceIPPolicy) validate() (errs *apis.FieldError) {
+	if len(p.AllowedCIDRs) == 0 {
+		errs = errs.Also(apis.ErrMissingField("allowedCIDRs"))
+	}
+	for i, cidr := range p.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(cidr, "allowedCIDRs", i))
+		}
+	}
+	for i, cidr := range p.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(cidr, "trustedProxies", i))
+		}
+	}
 	return errs
 }
 
@@ -312,6 +485,24 @@ func (t *EventListenerTrigger) validate(ctx context.Context) (errs *apis.FieldEr
 		errs = errs.Also(interceptor.validate(ctx).ViaField(fmt.Sprintf("interceptors[%d]", i)))
 	}
 
+	if t.RetryPolicy != nil {
+		errs = errs.Also(t.RetryPolicy.validate(ctx).ViaField("retryPolicy"))
+	}
+
+	if t.Timeout != nil {
+		errs = errs.Also(validateTimeout(ctx, t.Timeout, "timeout"))
+	}
+
+	errs = errs.Also(validatePath(ctx, t.Path, t.PathType))
+
+	if t.Concurrency != nil {
+		errs = errs.Also(t.Concurrency.validate(ctx).ViaField("concurrency"))
+	}
+
+	if t.Retention != nil {
+		errs = errs.Also(t.Retention.validate(ctx).ViaField("retention"))
+	}
+
 	// The trigger name is added as a label value for 'tekton.dev/trigger' so it must follow the k8s label guidelines:
 	// https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
 	if err := validation.IsValidLabelValue(t.Name); len(err) > 0 {
@@ -19,6 +19,7 @@ package v1beta1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -496,6 +497,243 @@ func Test_EventListenerValidate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "Valid EventListener with retryPolicy",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				RetryPolicy: &triggersv1beta1.RetryPolicy{
+					Attempts:       3,
+					Backoff:        &metav1.Duration{Duration: time.Second},
+					DeadLetterSink: &apis.URL{Scheme: "http", Host: "dead-letter.default.svc"},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}, {
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					RetryPolicy: &triggersv1beta1.RetryPolicy{
+						Attempts: 0,
+					},
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with deduplication",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Deduplication: &triggersv1beta1.Deduplication{
+					Key:   "$(header.X-GitHub-Delivery)",
+					TTL:   &metav1.Duration{Duration: 24 * time.Hour},
+					Store: triggersv1beta1.DeduplicationStoreLease,
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with cloudEventURI",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				CloudEventURI: &apis.URL{Scheme: "http", Host: "event-display.default.svc.cluster.local"},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with triggerInvocations",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				TriggerInvocations: &triggersv1beta1.TriggerInvocationPolicy{
+					TTL: &metav1.Duration{Duration: time.Hour},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with dry run",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				DryRun: &triggersv1beta1.DryRun{
+					TokenSecretRef: &triggersv1beta1.SecretRef{
+						SecretName: "dry-run",
+						SecretKey:  "token",
+					},
+					SubjectAccessReview: true,
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with payload policy",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				PayloadPolicy: &triggersv1beta1.PayloadPolicy{
+					MaxBodySize:         ptr.Int64(1024 * 1024),
+					AllowedContentTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with timeouts",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				ProcessingTimeout: &metav1.Duration{Duration: time.Minute},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Timeout: &metav1.Duration{Duration: 30 * time.Second},
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with client auth",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				ClientAuth: &triggersv1beta1.ClientAuth{
+					CABundleSecretRef: &triggersv1beta1.SecretRef{SecretName: "client-ca", SecretKey: "ca.crt"},
+					Mode:              triggersv1beta1.ClientAuthOptional,
+				},
+				Resources: triggersv1beta1.Resources{
+					KubernetesResource: tlsKubernetesResource(),
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with source IP policy",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				SourceIPPolicy: &triggersv1beta1.SourceIPPolicy{
+					AllowedCIDRs:   []string{"192.30.252.0/22", "2a0a:a440::/29"},
+					TrustedProxies: []string{"10.0.0.0/8"},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with concurrency",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Concurrency: &triggersv1beta1.Concurrency{
+						Key:    "$(body.pull_request.number)",
+						Policy: triggersv1beta1.ConcurrencyPolicyQueue,
+					},
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with retention",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Retention: &triggersv1beta1.Retention{
+					MaxAge: &metav1.Duration{Duration: 24 * time.Hour},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Retention: &triggersv1beta1.Retention{
+						KeepLast: ptr.Int32(10),
+						DryRun:   true,
+					},
+				}},
+			},
+		},
+	}, {
+		name: "Valid EventListener with paths",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+					Path:       "/github",
+					PathType:   triggersv1beta1.PathTypePrefix,
+				}, {
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Path: "/cron",
+				}},
+			},
+		},
 	}, {
 		name: "Valid event listener with TriggerGroup and namespaceSelector",
 		ctx:  ctxWithAlphaFieldsEnabled,
@@ -1216,82 +1454,589 @@ func TestEventListenerValidate_error(t *testing.T) {
 		},
 		wantErr: apis.ErrMultipleOneOf("spec.triggers[0].template or bindings or interceptors", "spec.triggers[0].triggerRef"),
 	}, {
-		name: "triggerGroups is not allowed if alpha fields are not enabled",
-		ctx:  context.Background(), // By default, enable-api-felds is set to stable, not alpha
+		name: "retryPolicy is not allowed if alpha fields are not enabled",
 		el: &triggersv1beta1.EventListener{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "name",
 				Namespace: "namespace",
 			},
 			Spec: triggersv1beta1.EventListenerSpec{
-				TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
-					Name: "my-group",
-					TriggerSelector: triggersv1beta1.EventListenerTriggerSelector{
-						NamespaceSelector: triggersv1beta1.NamespaceSelector{
-							MatchNames: []string{"default"},
-						},
-					},
-					Interceptors: []*triggersv1beta1.TriggerInterceptor{{
-						Ref: triggersv1beta1.InterceptorRef{
-							Name: "cel",
-						},
-						Params: []triggersv1beta1.InterceptorParams{{
-							Name:  "filter",
-							Value: test.ToV1JSON(t, "has(body.repository)"),
-						}},
-					}},
+				RetryPolicy: &triggersv1beta1.RetryPolicy{Attempts: 3},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
 				}},
 			},
 		},
-		wantErr: apis.ErrGeneric("spec.triggerGroups requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
-	},
-		{
-			name: "missing label and namespace selector",
-			ctx:  ctxWithAlphaFieldsEnabled,
-			el: &triggersv1beta1.EventListener{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "name",
-					Namespace: "namespace",
-				},
-				Spec: triggersv1beta1.EventListenerSpec{
-					TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
-						Name: "my-group",
-						Interceptors: []*triggersv1beta1.TriggerInterceptor{{
-							Ref: triggersv1beta1.InterceptorRef{
-								Name: "cel",
-							},
-							Params: []triggersv1beta1.InterceptorParams{{
-								Name:  "filter",
-								Value: test.ToV1JSON(t, "has(body.repository)"),
-							}},
-						}},
-					}},
-				},
+		wantErr: apis.ErrGeneric("retryPolicy requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "retryPolicy with too many attempts",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
 			},
-			wantErr: apis.ErrMissingOneOf("spec.triggerGroups[0].triggerSelector.labelSelector", "spec.triggerGroups[0].triggerSelector.namespaceSelector"),
-		}, {
-			name: "triggerGroup requires interceptor",
-			ctx:  ctxWithAlphaFieldsEnabled,
-			el: &triggersv1beta1.EventListener{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "name",
-					Namespace: "namespace",
-				},
-				Spec: triggersv1beta1.EventListenerSpec{
-					TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
-						Name: "my-group",
-						TriggerSelector: triggersv1beta1.EventListenerTriggerSelector{
-							LabelSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									"foo": "bar",
-								},
-							},
-						},
-					}},
-				},
+			Spec: triggersv1beta1.EventListenerSpec{
+				RetryPolicy: &triggersv1beta1.RetryPolicy{Attempts: 11},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
 			},
-			wantErr: apis.ErrMissingField("spec.triggerGroups[0].interceptors"),
-		}, {
+		},
+		wantErr: apis.ErrOutOfBoundsValue(11, 0, 10, "spec.retryPolicy.attempts"),
+	}, {
+		name: "retryPolicy with invalid backoff and deadLetterSink",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					RetryPolicy: &triggersv1beta1.RetryPolicy{
+						Backoff:        &metav1.Duration{Duration: -time.Second},
+						DeadLetterSink: &apis.URL{Scheme: "ftp", Host: "dead-letter"},
+					},
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("-1s", "spec.triggers[0].retryPolicy.backoff").Also(
+			apis.ErrInvalidValue("ftp://dead-letter", "spec.triggers[0].retryPolicy.deadLetterSink")),
+	}, {
+		name: "deduplication is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Deduplication: &triggersv1beta1.Deduplication{Key: "$(header.X-GitHub-Delivery)"},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.deduplication requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "deduplication key without expression",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Deduplication: &triggersv1beta1.Deduplication{
+					Key:   "X-GitHub-Delivery",
+					TTL:   &metav1.Duration{Duration: 0},
+					Store: "configmap",
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("X-GitHub-Delivery", "spec.deduplication.key").Also(
+			apis.ErrInvalidValue("0s", "spec.deduplication.ttl"),
+			apis.ErrInvalidValue("configmap", "spec.deduplication.store")),
+	}, {
+		name: "deduplication missing key",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Deduplication: &triggersv1beta1.Deduplication{},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingField("spec.deduplication.key"),
+	}, {
+		name: "cloudEventURI is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				CloudEventURI: &apis.URL{Scheme: "http", Host: "event-display"},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.cloudEventURI requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "cloudEventURI is not an HTTP URL",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				CloudEventURI: &apis.URL{Path: "/events"},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("/events", "spec.cloudEventURI"),
+	}, {
+		name: "triggerInvocations is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				TriggerInvocations: &triggersv1beta1.TriggerInvocationPolicy{},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.triggerInvocations requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "triggerInvocations with negative ttl",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				TriggerInvocations: &triggersv1beta1.TriggerInvocationPolicy{
+					TTL: &metav1.Duration{Duration: -time.Minute},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("-1m0s", "spec.triggerInvocations.ttl"),
+	}, {
+		name: "dryRun is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				DryRun: &triggersv1beta1.DryRun{SubjectAccessReview: true},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.dryRun requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "dryRun without any authorization",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				DryRun: &triggersv1beta1.DryRun{},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingOneOf("spec.dryRun.tokenSecretRef", "spec.dryRun.subjectAccessReview"),
+	}, {
+		name: "dryRun tokenSecretRef without secret key",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				DryRun: &triggersv1beta1.DryRun{
+					TokenSecretRef: &triggersv1beta1.SecretRef{SecretName: "dry-run"},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingField("spec.dryRun.tokenSecretRef.secretKey"),
+	}, {
+		name: "payloadPolicy is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				PayloadPolicy: &triggersv1beta1.PayloadPolicy{MaxBodySize: ptr.Int64(1024)},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.payloadPolicy requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "payloadPolicy with invalid maxBodySize and content types",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				PayloadPolicy: &triggersv1beta1.PayloadPolicy{
+					MaxBodySize:         ptr.Int64(0),
+					AllowedContentTypes: []string{"application/json", "json", "text/plain; charset=utf-8"},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(0, "spec.payloadPolicy.maxBodySize").
+			Also(apis.ErrInvalidArrayValue("json", "spec.payloadPolicy.allowedContentTypes", 1)).
+			Also(apis.ErrInvalidArrayValue("text/plain; charset=utf-8", "spec.payloadPolicy.allowedContentTypes", 2)),
+	}, {
+		name: "processingTimeout is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				ProcessingTimeout: &metav1.Duration{Duration: time.Minute},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.processingTimeout requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "invalid timeouts",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				ProcessingTimeout: &metav1.Duration{Duration: -time.Minute},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Timeout: &metav1.Duration{},
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("0s", "spec.triggers[0].timeout").Also(
+			apis.ErrInvalidValue("-1m0s", "spec.processingTimeout")),
+	}, {
+		name: "clientAuth is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				ClientAuth: &triggersv1beta1.ClientAuth{
+					CABundleSecretRef: &triggersv1beta1.SecretRef{SecretName: "client-ca", SecretKey: "ca.crt"},
+				},
+				Resources: triggersv1beta1.Resources{
+					KubernetesResource: tlsKubernetesResource(),
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.clientAuth requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "invalid clientAuth",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				ClientAuth: &triggersv1beta1.ClientAuth{
+					CABundleSecretRef: &triggersv1beta1.SecretRef{SecretName: "client-ca"},
+					Mode:              "Sometimes",
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingField("spec.clientAuth.caBundleSecretRef.secretKey").Also(
+			apis.ErrInvalidValue("Sometimes", "spec.clientAuth.mode"),
+			apis.ErrGeneric("clientAuth requires the TLS_CERT and TLS_KEY env vars of the EventListener to be set", "spec.clientAuth")),
+	}, {
+		name: "sourceIPPolicy is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				SourceIPPolicy: &triggersv1beta1.SourceIPPolicy{AllowedCIDRs: []string{"192.30.252.0/22"}},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.sourceIPPolicy requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "sourceIPPolicy without allowed CIDRs",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				SourceIPPolicy: &triggersv1beta1.SourceIPPolicy{},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingField("spec.sourceIPPolicy.allowedCIDRs"),
+	}, {
+		name: "sourceIPPolicy with invalid CIDRs",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				SourceIPPolicy: &triggersv1beta1.SourceIPPolicy{
+					AllowedCIDRs:   []string{"192.30.252.0/22", "192.30.252.1"},
+					TrustedProxies: []string{"10.0.0.0/33"},
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidArrayValue("192.30.252.1", "spec.sourceIPPolicy.allowedCIDRs", 1).
+			Also(apis.ErrInvalidArrayValue("10.0.0.0/33", "spec.sourceIPPolicy.trustedProxies", 0)),
+	}, {
+		name: "concurrency without key and with invalid policy",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Concurrency: &triggersv1beta1.Concurrency{Policy: "Drop"},
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingField("spec.triggers[0].concurrency.key").Also(
+			apis.ErrInvalidValue("Drop", "spec.triggers[0].concurrency.policy")),
+	}, {
+		name: "concurrency is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Concurrency: &triggersv1beta1.Concurrency{Key: "$(body.number)"},
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("concurrency requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "retention without a limit and with invalid values",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Retention: &triggersv1beta1.Retention{DryRun: true},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+					Retention: &triggersv1beta1.Retention{
+						KeepLast: ptr.Int32(-1),
+						MaxAge:   &metav1.Duration{},
+					},
+				}},
+			},
+		},
+		wantErr: apis.ErrMissingOneOf("spec.retention.keepLast", "spec.retention.maxAge").Also(
+			apis.ErrInvalidValue(-1, "spec.triggers[0].retention.keepLast"),
+			apis.ErrInvalidValue("0s", "spec.triggers[0].retention.maxAge")),
+	}, {
+		name: "retention is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Retention: &triggersv1beta1.Retention{KeepLast: ptr.Int32(10)},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Template: &triggersv1beta1.TriggerSpecTemplate{
+						Ref: ptr.String("tt"),
+					},
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("retention requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "path is not allowed if alpha fields are not enabled",
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+					Path:       "/github",
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("path requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "invalid path and pathType",
+		ctx:  ctxWithAlphaFieldsEnabled,
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					TriggerRef: "triggerref",
+					Path:       "github",
+					PathType:   "Regex",
+				}},
+				TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
+					Name: "my-group",
+					TriggerSelector: triggersv1beta1.EventListenerTriggerSelector{
+						NamespaceSelector: triggersv1beta1.NamespaceSelector{
+							MatchNames: []string{"default"},
+						},
+					},
+					Interceptors: []*triggersv1beta1.TriggerInterceptor{{
+						Ref: triggersv1beta1.InterceptorRef{
+							Name: "cel",
+						},
+					}},
+					PathType: triggersv1beta1.PathTypePrefix,
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("github", "spec.triggers[0].path").Also(
+			apis.ErrInvalidValue("Regex", "spec.triggers[0].pathType"),
+			apis.ErrMissingField("spec.triggerGroups[0].path")),
+	}, {
+		name: "triggerGroups is not allowed if alpha fields are not enabled",
+		ctx:  context.Background(), // By default, enable-api-felds is set to stable, not alpha
+		el: &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
+					Name: "my-group",
+					TriggerSelector: triggersv1beta1.EventListenerTriggerSelector{
+						NamespaceSelector: triggersv1beta1.NamespaceSelector{
+							MatchNames: []string{"default"},
+						},
+					},
+					Interceptors: []*triggersv1beta1.TriggerInterceptor{{
+						Ref: triggersv1beta1.InterceptorRef{
+							Name: "cel",
+						},
+						Params: []triggersv1beta1.InterceptorParams{{
+							Name:  "filter",
+							Value: test.ToV1JSON(t, "has(body.repository)"),
+						}},
+					}},
+				}},
+			},
+		},
+		wantErr: apis.ErrGeneric("spec.triggerGroups requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	},
+		{
+			name: "missing label and namespace selector",
+			ctx:  ctxWithAlphaFieldsEnabled,
+			el: &triggersv1beta1.EventListener{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: triggersv1beta1.EventListenerSpec{
+					TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
+						Name: "my-group",
+						Interceptors: []*triggersv1beta1.TriggerInterceptor{{
+							Ref: triggersv1beta1.InterceptorRef{
+								Name: "cel",
+							},
+							Params: []triggersv1beta1.InterceptorParams{{
+								Name:  "filter",
+								Value: test.ToV1JSON(t, "has(body.repository)"),
+							}},
+						}},
+					}},
+				},
+			},
+			wantErr: apis.ErrMissingOneOf("spec.triggerGroups[0].triggerSelector.labelSelector", "spec.triggerGroups[0].triggerSelector.namespaceSelector"),
+		}, {
+			name: "triggerGroup requires interceptor",
+			ctx:  ctxWithAlphaFieldsEnabled,
+			el: &triggersv1beta1.EventListener{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: triggersv1beta1.EventListenerSpec{
+					TriggerGroups: []triggersv1beta1.EventListenerTriggerGroup{{
+						Name: "my-group",
+						TriggerSelector: triggersv1beta1.EventListenerTriggerSelector{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"foo": "bar",
+								},
+							},
+						},
+					}},
+				},
+			},
+			wantErr: apis.ErrMissingField("spec.triggerGroups[0].interceptors"),
+		}, {
 			name: "empty spec for eventlistener",
 			ctx:  ctxWithAlphaFieldsEnabled,
 			el: &triggersv1beta1.EventListener{
@@ -1376,3 +2121,29 @@ func getValidRawData(t *testing.T) runtime.RawExtension {
 		}},
 	})
 }
+
+// tlsKubernetesResource returns a KubernetesResource that serves TLS.
+func tlsKubernetesResource() *triggersv1beta1.KubernetesResource {
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "tls-secret"},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	return &triggersv1beta1.KubernetesResource{
+		WithPodSpec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Env: []corev1.EnvVar{secretEnv("TLS_CERT", "tls.crt"), secretEnv("TLS_KEY", "tls.key")},
+					}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+)
+
+// ResourceReferenceRegexp captures the references of a resource template to the fields of
+// other resources of its TriggerTemplate, $(resources.REF.PATH). REF is the index or the
+// name of the referenced resource template, and PATH the dot separated path of the field.
+var ResourceReferenceRegexp = regexp.MustCompile(`\$\(resources\.([a-zA-Z0-9_-]+)\.([a-zA-Z0-9_.-]+)\)`)
+
+// resourceNameRegexp matches the names of resource templates, which cannot be mistaken
+// for indexes.
+var resourceNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// ResourceTemplateOrder returns the order the resources of the resource templates are
+// created in, as indexes into resources, and the indexes of the resource templates keyed
+// by the references to them. A resource is created after the resources it refers to or
+// depends on, and otherwise in the order of the resource templates.
+func ResourceTemplateOrder(resources []json.RawMessage) ([]int, map[string]int, error) {
+	refs := make(map[string]int, len(resources))
+	for i := range resources {
+		refs[strconv.Itoa(i)] = i
+	}
+	for i, rr := range resources {
+		name, ok := resourceAnnotations(rr)[triggers.ResourceNameAnnotation]
+		if !ok {
+			continue
+		}
+		if !resourceNameRegexp.MatchString(name) {
+			return nil, nil, fmt.Errorf("invalid name %q of resource template %d", name, i)
+		}
+		if j, ok := refs[name]; ok {
+			return nil, nil, fmt.Errorf("resource templates %d and %d have the same name %q", j, i, name)
+		}
+		refs[name] = i
+	}
+
+	deps := make([][]int, len(resources))
+	for i, rr := range resources {
+		var depRefs []string
+		if dependsOn, ok := resourceAnnotations(rr)[triggers.DependsOnAnnotation]; ok {
+			for _, ref := range strings.Split(dependsOn, ",") {
+				if ref = strings.TrimSpace(ref); ref != "" {
+					depRefs = append(depRefs, ref)
+				}
+			}
+		}
+		for _, m := range ResourceReferenceRegexp.FindAllSubmatch(rr, -1) {
+			depRefs = append(depRefs, string(m[1]))
+		}
+		for _, ref := range depRefs {
+			j, ok := refs[ref]
+			switch {
+			case !ok:
+				return nil, nil, fmt.Errorf("resource template %d refers to unknown resource %q", i, ref)
+			case j == i:
+				return nil, nil, fmt.Errorf("resource template %d refers to itself", i)
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+
+	order := make([]int, 0, len(resources))
+	created := make([]bool, len(resources))
+	for len(order) < len(resources) {
+		next := -1
+		for i := 0; i < len(resources) && next < 0; i++ {
+			if !created[i] && allCreated(deps[i], created) {
+				next = i
+			}
+		}
+		if next < 0 {
+			var cycle []int
+			for i := range resources {
+				if !created[i] {
+					cycle = append(cycle, i)
+				}
+			}
+			return nil, nil, fmt.Errorf("resource templates %v depend on each other", cycle)
+		}
+		created[next] = true
+		order = append(order, next)
+	}
+	return order, refs, nil
+}
+
+func allCreated(deps []int, created []bool) bool {
+	for _, j := range deps {
+		if !created[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceAnnotations returns the annotations of the resource template rr.
+func resourceAnnotations(rr []byte) map[string]string {
+	var resource struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	// Templates that are not valid JSON are reported by EnsureAllowedType.
+	if err := json.Unmarshal(rr, &resource); err != nil {
+		return nil
+	}
+	return resource.Metadata.Annotations
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+)
+
+func TestResourceTemplateOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []string
+		wantOrder []int
+		wantRefs  map[string]int
+	}{{
+		name:      "no references",
+		resources: []string{`{"kind":"PersistentVolumeClaim"}`, `{"kind":"PipelineRun"}`},
+		wantOrder: []int{0, 1},
+		wantRefs:  map[string]int{"0": 0, "1": 1},
+	}, {
+		name: "reference to a later resource",
+		resources: []string{
+			`{"kind":"PersistentVolumeClaim","metadata":{"ownerReferences":[{"uid":"$(resources.run.metadata.uid)"}]}}`,
+			`{"kind":"ConfigMap"}`,
+			`{"kind":"PipelineRun","metadata":{"annotations":{"triggers.tekton.dev/resource-name":"run"}}}`,
+		},
+		wantOrder: []int{1, 2, 0},
+		wantRefs:  map[string]int{"0": 0, "1": 1, "2": 2, "run": 2},
+	}, {
+		name: "depends on",
+		resources: []string{
+			`{"kind":"PipelineRun","metadata":{"annotations":{"triggers.tekton.dev/depends-on":"pvc, 1"}}}`,
+			`{"kind":"ConfigMap"}`,
+			`{"kind":"PersistentVolumeClaim","metadata":{"annotations":{"triggers.tekton.dev/resource-name":"pvc"}}}`,
+		},
+		wantOrder: []int{1, 2, 0},
+		wantRefs:  map[string]int{"0": 0, "1": 1, "2": 2, "pvc": 2},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			order, refs, err := v1beta1.ResourceTemplateOrder(rawMessages(tc.resources))
+			if err != nil {
+				t.Fatalf("ResourceTemplateOrder() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantOrder, order); diff != "" {
+				t.Errorf("ResourceTemplateOrder() order diff (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRefs, refs); diff != "" {
+				t.Errorf("ResourceTemplateOrder() refs diff (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceTemplateOrder_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []string
+	}{{
+		name:      "unknown reference",
+		resources: []string{`{"metadata":{"name":"$(resources.1.metadata.name)"}}`},
+	}, {
+		name:      "reference to itself",
+		resources: []string{`{"metadata":{"name":"$(resources.0.metadata.name)"}}`},
+	}, {
+		name: "duplicate names",
+		resources: []string{
+			`{"metadata":{"annotations":{"triggers.tekton.dev/resource-name":"run"}}}`,
+			`{"metadata":{"annotations":{"triggers.tekton.dev/resource-name":"run"}}}`,
+		},
+	}, {
+		name:      "name that is an index",
+		resources: []string{`{"metadata":{"annotations":{"triggers.tekton.dev/resource-name":"1"}}}`},
+	}, {
+		name: "cycle",
+		resources: []string{
+			`{"metadata":{"annotations":{"triggers.tekton.dev/depends-on":"1"}}}`,
+			`{"metadata":{"annotations":{"triggers.tekton.dev/depends-on":"0"}}}`,
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := v1beta1.ResourceTemplateOrder(rawMessages(tc.resources)); err == nil {
+				t.Error("ResourceTemplateOrder() expected error")
+			}
+		})
+	}
+}
+
+func rawMessages(resources []string) []json.RawMessage {
+	raw := make([]json.RawMessage, len(resources))
+	for i, r := range resources {
+		raw[i] = json.RawMessage(r)
+	}
+	return raw
+}
@@ -37,6 +37,25 @@ type TriggerResourceTemplate struct {
 	runtime.RawExtension `json:",inline"`
 }
 
+// CreatePolicy is how the resource of a resource template is written when the resource
+// might already exist. It is set with the triggers.tekton.dev/create-policy annotation
+// of the resource template.
+type CreatePolicy string
+
+const (
+	// CreatePolicyCreate creates the resource, and fails if it already exists. It is the
+	// policy of resource templates that do not set one.
+	CreatePolicyCreate CreatePolicy = "Create"
+	// CreatePolicyCreateOrUpdate creates the resource, or replaces it if it already exists.
+	CreatePolicyCreateOrUpdate CreatePolicy = "CreateOrUpdate"
+	// CreatePolicyApply applies the resource with server-side apply, and fails if another
+	// field manager owns the fields it sets.
+	CreatePolicyApply CreatePolicy = "Apply"
+	// CreatePolicyPatch merges the resource into the existing resource with a JSON merge
+	// patch, and fails if the resource does not exist.
+	CreatePolicyPatch CreatePolicy = "Patch"
+)
+
 // TriggerTemplateStatus describes the desired state of TriggerTemplate
 type TriggerTemplateStatus struct{}
 
@@ -18,12 +18,14 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/triggers/pkg/apis/config"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -50,13 +52,17 @@ func (s *TriggerTemplateSpec) validate(ctx context.Context) (errs *apis.FieldErr
 	if len(s.ResourceTemplates) == 0 {
 		errs = errs.Also(apis.ErrMissingField("resourcetemplates"))
 	}
-	errs = errs.Also(validateResourceTemplates(s.ResourceTemplates).ViaField("resourcetemplates"))
+	errs = errs.Also(validateResourceTemplates(ctx, s.ResourceTemplates).ViaField("resourcetemplates"))
 	errs = errs.Also(verifyParamDeclarations(s.Params, s.ResourceTemplates).ViaField("resourcetemplates"))
 	return errs
 }
 
-func validateResourceTemplates(templates []TriggerResourceTemplate) (errs *apis.FieldError) {
+func validateResourceTemplates(ctx context.Context, templates []TriggerResourceTemplate) (errs *apis.FieldError) {
 	for i, trt := range templates {
+		policy, hasPolicy := createPolicy(trt.RawExtension)
+		if hasPolicy {
+			errs = errs.Also(validateCreatePolicy(ctx, policy).ViaField(fmt.Sprintf("[%d].metadata.annotations", i)))
+		}
 		if err := config.EnsureAllowedType(trt.RawExtension); err != nil {
 			if runtime.IsMissingVersion(err) {
 				errs = errs.Also(apis.ErrMissingField(fmt.Sprintf("[%d].apiVersion", i)))
@@ -64,7 +70,9 @@ func validateResourceTemplates(templates []TriggerResourceTemplate) (errs *apis.
 			if runtime.IsMissingKind(err) {
 				errs = errs.Also(apis.ErrMissingField(fmt.Sprintf("[%d].kind", i)))
 			}
-			if runtime.IsNotRegisteredError(err) {
+			// Resources with a create policy are meant to be maintained by the Trigger, and
+			// can be of any kind, such as a ConfigMap.
+			if runtime.IsNotRegisteredError(err) && !hasPolicy {
 				errStr := err.Error()
 				if strings.Contains(errStr, "in scheme") {
 					// not registered error messages currently include the scheme variable location in your file,
@@ -80,9 +88,56 @@ func validateResourceTemplates(templates []TriggerResourceTemplate) (errs *apis.
 			// we allow structural errors because of param substitution
 		}
 	}
+	return errs.Also(validateResourceOrder(ctx, templates))
+}
+
+// validateResourceOrder validates the references of the resource templates to each other,
+// and the hints that order the creation of their resources.
+func validateResourceOrder(ctx context.Context, templates []TriggerResourceTemplate) (errs *apis.FieldError) {
+	resources := make([]json.RawMessage, len(templates))
+	ordered := false
+	for i, trt := range templates {
+		resources[i] = trt.Raw
+		annotations := resourceAnnotations(trt.Raw)
+		_, named := annotations[triggers.ResourceNameAnnotation]
+		_, dependsOn := annotations[triggers.DependsOnAnnotation]
+		wait, waits := annotations[triggers.WaitForReadyAnnotation]
+		if waits && wait != "true" && wait != "false" {
+			errs = errs.Also(apis.ErrInvalidValue(wait, triggers.WaitForReadyAnnotation).ViaField(fmt.Sprintf("[%d].metadata.annotations", i)))
+		}
+		ordered = ordered || named || dependsOn || waits || ResourceReferenceRegexp.Match(trt.Raw)
+	}
+	if !ordered {
+		return nil
+	}
+	if err := ValidateEnabledAPIFields(ctx, "resource references", config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	if _, _, err := ResourceTemplateOrder(resources); err != nil {
+		errs = errs.Also(apis.ErrGeneric(err.Error(), apis.CurrentField))
+	}
 	return errs
 }
 
+// createPolicy returns the create policy annotation of the resource template rt, and
+// whether it has one.
+func createPolicy(rt runtime.RawExtension) (CreatePolicy, bool) {
+	policy, ok := resourceAnnotations(rt.Raw)[triggers.CreatePolicyAnnotation]
+	return CreatePolicy(policy), ok
+}
+
+func validateCreatePolicy(ctx context.Context, policy CreatePolicy) *apis.FieldError {
+	if err := ValidateEnabledAPIFields(ctx, triggers.CreatePolicyAnnotation, config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	switch policy {
+	case CreatePolicyCreate, CreatePolicyCreateOrUpdate, CreatePolicyApply, CreatePolicyPatch:
+		return nil
+	default:
+		return apis.ErrInvalidValue(policy, triggers.CreatePolicyAnnotation)
+	}
+}
+
 // Verify every param in the ResourceTemplates is declared with a ParamSpec
 func verifyParamDeclarations(params []ParamSpec, templates []TriggerResourceTemplate) *apis.FieldError {
 	declaredParamNames := sets.NewString()
@@ -389,3 +389,152 @@ func TestTriggerTemplate_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTriggerTemplate_Validate_CreatePolicy(t *testing.T) {
+	ctxWithAlphaFieldsEnabled, err := test.FeatureFlagsToContext(context.Background(), map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error initializing feature flags: %v", err)
+	}
+	configMapTemplate := func(policy string) runtime.RawExtension {
+		return runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"preview","annotations":{"triggers.tekton.dev/create-policy":"` + policy + `"}}}`)}
+	}
+	template := func(rt runtime.RawExtension) *v1beta1.TriggerTemplate {
+		return &v1beta1.TriggerTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tt",
+				Namespace: "foo",
+			},
+			Spec: v1beta1.TriggerTemplateSpec{
+				ResourceTemplates: []v1beta1.TriggerResourceTemplate{{RawExtension: rt}},
+			},
+		}
+	}
+
+	tcs := []struct {
+		name     string
+		template *v1beta1.TriggerTemplate
+		ctx      context.Context
+		want     *apis.FieldError
+	}{{
+		name:     "resource of any kind with a create policy",
+		template: template(configMapTemplate("CreateOrUpdate")),
+		ctx:      ctxWithAlphaFieldsEnabled,
+	}, {
+		name:     "resource without a create policy must be a Tekton resource",
+		template: template(runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"preview"}}`)}),
+		ctx:      ctxWithAlphaFieldsEnabled,
+		want: &apis.FieldError{
+			Message: `invalid value: no kind "ConfigMap" is registered for version "v1"`,
+			Paths:   []string{"spec.resourcetemplates[0]"},
+		},
+	}, {
+		name:     "invalid create policy",
+		template: template(configMapTemplate("Replace")),
+		ctx:      ctxWithAlphaFieldsEnabled,
+		want: &apis.FieldError{
+			Message: "invalid value: Replace",
+			Paths:   []string{"spec.resourcetemplates[0].metadata.annotations.triggers.tekton.dev/create-policy"},
+		},
+	}, {
+		name:     "create policy is not allowed if alpha fields are not enabled",
+		template: template(configMapTemplate("Apply")),
+		ctx:      context.Background(),
+		want:     apis.ErrGeneric(`triggers.tekton.dev/create-policy requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.template.Validate(tc.ctx)
+			if d := cmp.Diff(got.Error(), tc.want.Error()); d != "" {
+				t.Errorf("TriggerTemplate Validation failed: %s", d)
+			}
+		})
+	}
+}
+
+func TestTriggerTemplate_Validate_ResourceOrder(t *testing.T) {
+	ctxWithAlphaFieldsEnabled, err := test.FeatureFlagsToContext(context.Background(), map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error initializing feature flags: %v", err)
+	}
+	pipelineRunTemplate := func(metadata string) v1beta1.TriggerResourceTemplate {
+		return v1beta1.TriggerResourceTemplate{RawExtension: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"tekton.dev/v1beta1","kind":"PipelineRun","metadata":` + metadata + `}`),
+		}}
+	}
+	template := func(rts ...v1beta1.TriggerResourceTemplate) *v1beta1.TriggerTemplate {
+		return &v1beta1.TriggerTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tt",
+				Namespace: "foo",
+			},
+			Spec: v1beta1.TriggerTemplateSpec{ResourceTemplates: rts},
+		}
+	}
+
+	tcs := []struct {
+		name     string
+		template *v1beta1.TriggerTemplate
+		ctx      context.Context
+		want     *apis.FieldError
+	}{{
+		name: "references to named and indexed resources",
+		template: template(
+			pipelineRunTemplate(`{"name":"$(resources.first.metadata.name)-$(resources.2.metadata.uid)"}`),
+			pipelineRunTemplate(`{"name":"first","annotations":{"triggers.tekton.dev/resource-name":"first","triggers.tekton.dev/wait-for-ready":"true"}}`),
+			pipelineRunTemplate(`{"name":"second","annotations":{"triggers.tekton.dev/depends-on":"first"}}`),
+		),
+		ctx: ctxWithAlphaFieldsEnabled,
+	}, {
+		name: "reference to unknown resource",
+		template: template(
+			pipelineRunTemplate(`{"name":"$(resources.missing.metadata.name)"}`),
+		),
+		ctx: ctxWithAlphaFieldsEnabled,
+		want: &apis.FieldError{
+			Message: `resource template 0 refers to unknown resource "missing"`,
+			Paths:   []string{"spec.resourcetemplates"},
+		},
+	}, {
+		name: "resources that depend on each other",
+		template: template(
+			pipelineRunTemplate(`{"name":"$(resources.1.metadata.name)"}`),
+			pipelineRunTemplate(`{"name":"second","annotations":{"triggers.tekton.dev/depends-on":"0"}}`),
+		),
+		ctx: ctxWithAlphaFieldsEnabled,
+		want: &apis.FieldError{
+			Message: "resource templates [0 1] depend on each other",
+			Paths:   []string{"spec.resourcetemplates"},
+		},
+	}, {
+		name: "invalid wait for ready",
+		template: template(
+			pipelineRunTemplate(`{"name":"first","annotations":{"triggers.tekton.dev/wait-for-ready":"yes"}}`),
+		),
+		ctx: ctxWithAlphaFieldsEnabled,
+		want: &apis.FieldError{
+			Message: "invalid value: yes",
+			Paths:   []string{"spec.resourcetemplates[0].metadata.annotations.triggers.tekton.dev/wait-for-ready"},
+		},
+	}, {
+		name: "resource references are not allowed if alpha fields are not enabled",
+		template: template(
+			pipelineRunTemplate(`{"name":"first","annotations":{"triggers.tekton.dev/resource-name":"first"}}`),
+		),
+		ctx:  context.Background(),
+		want: apis.ErrGeneric(`resource references requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.template.Validate(tc.ctx)
+			if d := cmp.Diff(got.Error(), tc.want.Error()); d != "" {
+				t.Errorf("TriggerTemplate Validation failed: %s", d)
+			}
+		})
+	}
+}
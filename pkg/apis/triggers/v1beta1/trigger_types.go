@@ -38,6 +38,96 @@ type TriggerSpec struct {
 	// as the Trigger itself
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// RetryPolicy overrides the RetryPolicy of the EventListener for this Trigger
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// Timeout bounds the time spent processing an event for this Trigger,
+	// including its interceptors and the creation of its resources
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Path restricts the Trigger to requests with a matching URL path
+	// +optional
+	Path string `json:"path,omitempty"`
+	// PathType determines how Path is matched, either Exact or Prefix. Defaults to Exact.
+	// +optional
+	PathType PathType `json:"pathType,omitempty"`
+	// Concurrency controls the runs created by the Trigger for events with the same key
+	// +optional
+	Concurrency *Concurrency `json:"concurrency,omitempty"`
+	// Retention overrides the Retention of the EventListener for this Trigger
+	// +optional
+	Retention *Retention `json:"retention,omitempty"`
+}
+
+// PathType determines how the path of a Trigger is matched against the URL path of a request.
+type PathType string
+
+const (
+	// PathTypeExact matches the URL path exactly.
+	PathTypeExact PathType = "Exact"
+	// PathTypePrefix matches URL paths that start with the path, element by element.
+	// For example, /github matches /github and /github/push, but not /githubapp.
+	PathTypePrefix PathType = "Prefix"
+)
+
+// Concurrency groups the PipelineRuns and TaskRuns created by a Trigger for events with
+// the same key, and determines what happens to the runs of a group that are in progress
+// when an event of the group is received. The policy only holds within a single
+// EventListener replica.
+type Concurrency struct {
+	// Key identifies the group of an event. It is resolved like a TriggerBinding param
+	// value, for example $(body.pull_request.number).
+	Key string `json:"key"`
+	// Policy is what happens when runs of the group are in progress, one of
+	// CancelInProgress, Queue or Skip. Defaults to CancelInProgress.
+	// +optional
+	Policy ConcurrencyPolicy `json:"policy,omitempty"`
+}
+
+// ConcurrencyPolicy determines what happens when an event is received for a concurrency
+// group whose runs are in progress.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyPolicyCancelInProgress creates the runs for the event, and cancels the
+	// runs of the group that are in progress.
+	ConcurrencyPolicyCancelInProgress ConcurrencyPolicy = "CancelInProgress"
+	// ConcurrencyPolicyQueue creates the PipelineRuns for the event as pending, and starts
+	// them once the runs of the group that are in progress complete.
+	ConcurrencyPolicyQueue ConcurrencyPolicy = "Queue"
+	// ConcurrencyPolicySkip does not create resources for the event.
+	ConcurrencyPolicySkip ConcurrencyPolicy = "Skip"
+)
+
+// Retention determines how long the resources created by a Trigger are kept. Resources
+// that are older than MaxAge, or that are not among the KeepLast most recent resources
+// of their kind, are deleted once they completed.
+type Retention struct {
+	// KeepLast is the number of most recent resources of each kind that are kept.
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+	// MaxAge is how long resources are kept after they were created.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// DryRun reports the resources that would be deleted without deleting them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// RetryPolicy defines how failed ClusterInterceptor calls and resource creation
+// are retried, and where events are sent when processing them still fails.
+type RetryPolicy struct {
+	// Attempts is the number of times a failed request is retried.
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+	// Backoff is the delay before the first retry. The delay doubles with each
+	// following retry. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+	// DeadLetterSink is the URI that events are sent to when processing them
+	// still fails after all retries.
+	// +optional
+	DeadLetterSink *apis.URL `json:"deadLetterSink,omitempty"`
 }
 
 type TriggerSpecTemplate struct {
@@ -20,12 +20,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/triggers/pkg/apis/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
+// maxRetryAttempts is the maximum number of retries a RetryPolicy can specify.
+const maxRetryAttempts = 10
+
 // Validate validates a Trigger
 func (t *Trigger) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
@@ -46,6 +52,104 @@ func (t *TriggerSpec) validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(interceptor.validate(ctx).ViaField(fmt.Sprintf("interceptors[%d]", i)))
 	}
 
+	if t.RetryPolicy != nil {
+		errs = errs.Also(t.RetryPolicy.validate(ctx).ViaField("retryPolicy"))
+	}
+
+	if t.Timeout != nil {
+		errs = errs.Also(validateTimeout(ctx, t.Timeout, "timeout"))
+	}
+
+	errs = errs.Also(validatePath(ctx, t.Path, t.PathType))
+
+	if t.Concurrency != nil {
+		errs = errs.Also(t.Concurrency.validate(ctx).ViaField("concurrency"))
+	}
+	if t.Retention != nil {
+		errs = errs.Also(t.Retention.validate(ctx).ViaField("retention"))
+	}
+
+	return errs
+}
+
+// validateTimeout validates a timeout of a Trigger or EventListener.
+func validateTimeout(ctx context.Context, timeout *metav1.Duration, field string) *apis.FieldError {
+	if err := ValidateEnabledAPIFields(ctx, field, config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	if timeout.Duration <= 0 {
+		return apis.ErrInvalidValue(timeout.Duration.String(), field)
+	}
+	return nil
+}
+
+// validatePath validates the path and pathType of a Trigger or TriggerGroup.
+func validatePath(ctx context.Context, path string, pathType PathType) (errs *apis.FieldError) {
+	if path == "" && pathType == "" {
+		return nil
+	}
+	if err := ValidateEnabledAPIFields(ctx, "path", config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	if path == "" {
+		errs = errs.Also(apis.ErrMissingField("path"))
+	} else if !strings.HasPrefix(path, "/") {
+		errs = errs.Also(apis.ErrInvalidValue(path, "path"))
+	}
+	switch pathType {
+	case "", PathTypeExact, PathTypePrefix:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(pathType, "pathType"))
+	}
+	return errs
+}
+
+func (c *Concurrency) validate(ctx context.Context) (errs *apis.FieldError) {
+	if err := ValidateEnabledAPIFields(ctx, "concurrency", config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	if c.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	switch c.Policy {
+	case "", ConcurrencyPolicyCancelInProgress, ConcurrencyPolicyQueue, ConcurrencyPolicySkip:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(c.Policy, "policy"))
+	}
+	return errs
+}
+
+func (r *Retention) validate(ctx context.Context) (errs *apis.FieldError) {
+	if err := ValidateEnabledAPIFields(ctx, "retention", config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	if r.KeepLast == nil && r.MaxAge == nil {
+		errs = errs.Also(apis.ErrMissingOneOf("keepLast", "maxAge"))
+	}
+	if r.KeepLast != nil && *r.KeepLast < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*r.KeepLast, "keepLast"))
+	}
+	if r.MaxAge != nil && r.MaxAge.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.MaxAge.Duration.String(), "maxAge"))
+	}
+	return errs
+}
+
+func (p *RetryPolicy) validate(ctx context.Context) (errs *apis.FieldError) {
+	if err := ValidateEnabledAPIFields(ctx, "retryPolicy", config.AlphaAPIFieldValue); err != nil {
+		return err
+	}
+	if p.Attempts < 0 || p.Attempts > maxRetryAttempts {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(p.Attempts, 0, maxRetryAttempts, "attempts"))
+	}
+	if p.Backoff != nil && p.Backoff.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.Backoff.Duration.String(), "backoff"))
+	}
+	if p.DeadLetterSink != nil {
+		if p.DeadLetterSink.Scheme != "http" && p.DeadLetterSink.Scheme != "https" || p.DeadLetterSink.Host == "" {
+			errs = errs.Also(apis.ErrInvalidValue(p.DeadLetterSink.String(), "deadLetterSink"))
+		}
+	}
 	return errs
 }
 
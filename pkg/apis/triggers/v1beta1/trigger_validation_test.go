@@ -19,6 +19,7 @@ package v1beta1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
@@ -398,6 +399,51 @@ func TestTriggerValidate_error(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "retryPolicy requires alpha fields",
+		tr: &v1beta1.Trigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			Spec: v1beta1.TriggerSpec{
+				Template:    v1beta1.TriggerSpecTemplate{Ref: ptr.String("tt")},
+				RetryPolicy: &v1beta1.RetryPolicy{Attempts: 3},
+			},
+		},
+	}, {
+		name: "timeout requires alpha fields",
+		tr: &v1beta1.Trigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			Spec: v1beta1.TriggerSpec{
+				Template: v1beta1.TriggerSpecTemplate{Ref: ptr.String("tt")},
+				Timeout:  &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}, {
+		name: "path requires alpha fields",
+		tr: &v1beta1.Trigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			Spec: v1beta1.TriggerSpec{
+				Template: v1beta1.TriggerSpecTemplate{Ref: ptr.String("tt")},
+				Path:     "/github",
+			},
+		},
+	}, {
+		name: "concurrency requires alpha fields",
+		tr: &v1beta1.Trigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			Spec: v1beta1.TriggerSpec{
+				Template:    v1beta1.TriggerSpecTemplate{Ref: ptr.String("tt")},
+				Concurrency: &v1beta1.Concurrency{Key: "$(body.number)"},
+			},
+		},
+	}, {
+		name: "retention requires alpha fields",
+		tr: &v1beta1.Trigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "name"},
+			Spec: v1beta1.TriggerSpec{
+				Template:  v1beta1.TriggerSpecTemplate{Ref: ptr.String("tt")},
+				Retention: &v1beta1.Retention{KeepLast: ptr.Int32(5)},
+			},
+		},
 	}, {
 		name: "Trigger template missing both ref and spec",
 		tr: &v1beta1.Trigger{
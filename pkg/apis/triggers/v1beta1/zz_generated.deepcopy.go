@@ -91,6 +91,27 @@ func (in *CELOverlay) DeepCopy() *CELOverlay {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientAuth) DeepCopyInto(out *ClientAuth) {
+	*out = *in
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientAuth.
+func (in *ClientAuth) DeepCopy() *ClientAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerBinding) DeepCopyInto(out *ClusterTriggerBinding) {
 	*out = *in
@@ -152,6 +173,22 @@ func (in *ClusterTriggerBindingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Concurrency) DeepCopyInto(out *Concurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Concurrency.
+func (in *Concurrency) DeepCopy() *Concurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(Concurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomResource) DeepCopyInto(out *CustomResource) {
 	*out = *in
@@ -169,6 +206,48 @@ func (in *CustomResource) DeepCopy() *CustomResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Deduplication) DeepCopyInto(out *Deduplication) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deduplication.
+func (in *Deduplication) DeepCopy() *Deduplication {
+	if in == nil {
+		return nil
+	}
+	out := new(Deduplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRun) DeepCopyInto(out *DryRun) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRun.
+func (in *DryRun) DeepCopy() *DryRun {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventListener) DeepCopyInto(out *EventListener) {
 	*out = *in
@@ -270,6 +349,56 @@ func (in *EventListenerSpec) DeepCopyInto(out *EventListenerSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(Retention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deduplication != nil {
+		in, out := &in.Deduplication, &out.Deduplication
+		*out = new(Deduplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEventURI != nil {
+		in, out := &in.CloudEventURI, &out.CloudEventURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TriggerInvocations != nil {
+		in, out := &in.TriggerInvocations, &out.TriggerInvocations
+		*out = new(TriggerInvocationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PayloadPolicy != nil {
+		in, out := &in.PayloadPolicy, &out.PayloadPolicy
+		*out = new(PayloadPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProcessingTimeout != nil {
+		in, out := &in.ProcessingTimeout, &out.ProcessingTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ClientAuth != nil {
+		in, out := &in.ClientAuth, &out.ClientAuth
+		*out = new(ClientAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceIPPolicy != nil {
+		in, out := &in.SourceIPPolicy, &out.SourceIPPolicy
+		*out = new(SourceIPPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -332,6 +461,26 @@ func (in *EventListenerTrigger) DeepCopyInto(out *EventListenerTrigger) {
 			}
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(Retention)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -560,6 +709,32 @@ func (in *ParamSpec) DeepCopy() *ParamSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PayloadPolicy) DeepCopyInto(out *PayloadPolicy) {
+	*out = *in
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllowedContentTypes != nil {
+		in, out := &in.AllowedContentTypes, &out.AllowedContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadPolicy.
+func (in *PayloadPolicy) DeepCopy() *PayloadPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PayloadPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
 	*out = *in
@@ -616,6 +791,58 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Retention) DeepCopyInto(out *Retention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retention.
+func (in *Retention) DeepCopy() *Retention {
+	if in == nil {
+		return nil
+	}
+	out := new(Retention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeadLetterSink != nil {
+		in, out := &in.DeadLetterSink, &out.DeadLetterSink
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
@@ -632,6 +859,32 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceIPPolicy) DeepCopyInto(out *SourceIPPolicy) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrustedProxies != nil {
+		in, out := &in.TrustedProxies, &out.TrustedProxies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceIPPolicy.
+func (in *SourceIPPolicy) DeepCopy() *SourceIPPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceIPPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -840,6 +1093,27 @@ func (in *TriggerInterceptor) DeepCopy() *TriggerInterceptor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerInvocationPolicy) DeepCopyInto(out *TriggerInvocationPolicy) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerInvocationPolicy.
+func (in *TriggerInvocationPolicy) DeepCopy() *TriggerInvocationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerInvocationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerList) DeepCopyInto(out *TriggerList) {
 	*out = *in
@@ -916,6 +1190,26 @@ func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
 			}
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(Retention)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -24,6 +24,9 @@ import (
 
 const (
 	PayloadValidationAnnotation = "tekton.dev/payload-validation"
+	// SyncResponseAnnotation makes the EventListener wait for all triggers to
+	// be processed before responding to an event.
+	SyncResponseAnnotation = "tekton.dev/sync-response"
 )
 
 func ValidateAnnotations(annotations map[string]string) *apis.FieldError {
@@ -35,5 +38,11 @@ func ValidateAnnotations(annotations map[string]string) *apis.FieldError {
 		}
 	}
 
+	if value, ok := annotations[SyncResponseAnnotation]; ok {
+		if value != "true" && value != "false" {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s annotation must have value 'true' or 'false'", SyncResponseAnnotation), "metadata.annotations"))
+		}
+	}
+
 	return errs
 }
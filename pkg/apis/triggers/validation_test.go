@@ -35,3 +35,19 @@ func Test_PayloadValidationAnnotation_InvalidValue(t *testing.T) {
 		t.Errorf("Expected Error but got nil")
 	}
 }
+
+func Test_SyncResponseAnnotation_Valid(t *testing.T) {
+	annotations := map[string]string{SyncResponseAnnotation: "true"}
+	err := ValidateAnnotations(annotations)
+	if err != nil {
+		t.Errorf("Unexpected Error: %v", err)
+	}
+}
+
+func Test_SyncResponseAnnotation_InvalidValue(t *testing.T) {
+	annotations := map[string]string{SyncResponseAnnotation: "yes"}
+	err := ValidateAnnotations(annotations)
+	if err == nil {
+		t.Errorf("Expected Error but got nil")
+	}
+}
@@ -48,6 +48,10 @@ func (c *FakeTriggersV1alpha1) TriggerBindings(namespace string) v1alpha1.Trigge
 	return &FakeTriggerBindings{c, namespace}
 }
 
+func (c *FakeTriggersV1alpha1) TriggerInvocations(namespace string) v1alpha1.TriggerInvocationInterface {
+	return &FakeTriggerInvocations{c, namespace}
+}
+
 func (c *FakeTriggersV1alpha1) TriggerTemplates(namespace string) v1alpha1.TriggerTemplateInterface {
 	return &FakeTriggerTemplates{c, namespace}
 }
@@ -28,4 +28,6 @@ type TriggerExpansion interface{}
 
 type TriggerBindingExpansion interface{}
 
+type TriggerInvocationExpansion interface{}
+
 type TriggerTemplateExpansion interface{}
@@ -31,6 +31,7 @@ type TriggersV1alpha1Interface interface {
 	EventListenersGetter
 	TriggersGetter
 	TriggerBindingsGetter
+	TriggerInvocationsGetter
 	TriggerTemplatesGetter
 }
 
@@ -59,6 +60,10 @@ func (c *TriggersV1alpha1Client) TriggerBindings(namespace string) TriggerBindin
 	return newTriggerBindings(c, namespace)
 }
 
+func (c *TriggersV1alpha1Client) TriggerInvocations(namespace string) TriggerInvocationInterface {
+	return newTriggerInvocations(c, namespace)
+}
+
 func (c *TriggersV1alpha1Client) TriggerTemplates(namespace string) TriggerTemplateInterface {
 	return newTriggerTemplates(c, namespace)
 }
@@ -34,6 +34,8 @@ type Interface interface {
 	Triggers() TriggerInformer
 	// TriggerBindings returns a TriggerBindingInformer.
 	TriggerBindings() TriggerBindingInformer
+	// TriggerInvocations returns a TriggerInvocationInformer.
+	TriggerInvocations() TriggerInvocationInformer
 	// TriggerTemplates returns a TriggerTemplateInformer.
 	TriggerTemplates() TriggerTemplateInformer
 }
@@ -74,6 +76,11 @@ func (v *version) TriggerBindings() TriggerBindingInformer {
 	return &triggerBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TriggerInvocations returns a TriggerInvocationInformer.
+func (v *version) TriggerInvocations() TriggerInvocationInformer {
+	return &triggerInvocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TriggerTemplates returns a TriggerTemplateInformer.
 func (v *version) TriggerTemplates() TriggerTemplateInformer {
 	return &triggerTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
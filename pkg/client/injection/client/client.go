@@ -760,6 +760,137 @@ func (w *wrapTriggersV1alpha1TriggerBindingImpl) Watch(ctx context.Context, opts
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapTriggersV1alpha1) TriggerInvocations(namespace string) typedtriggersv1alpha1.TriggerInvocationInterface {
+	return &wrapTriggersV1alpha1TriggerInvocationImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "triggers.tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "triggerinvocations",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTriggersV1alpha1TriggerInvocationImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtriggersv1alpha1.TriggerInvocationInterface = (*wrapTriggersV1alpha1TriggerInvocationImpl)(nil)
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) Create(ctx context.Context, in *v1alpha1.TriggerInvocation, opts v1.CreateOptions) (*v1alpha1.TriggerInvocation, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "triggers.tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "TriggerInvocation",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TriggerInvocation{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TriggerInvocation, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TriggerInvocation{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.TriggerInvocationList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TriggerInvocationList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TriggerInvocation, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TriggerInvocation{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) Update(ctx context.Context, in *v1alpha1.TriggerInvocation, opts v1.UpdateOptions) (*v1alpha1.TriggerInvocation, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "triggers.tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "TriggerInvocation",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TriggerInvocation{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) UpdateStatus(ctx context.Context, in *v1alpha1.TriggerInvocation, opts v1.UpdateOptions) (*v1alpha1.TriggerInvocation, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "triggers.tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "TriggerInvocation",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TriggerInvocation{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTriggersV1alpha1TriggerInvocationImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapTriggersV1alpha1) TriggerTemplates(namespace string) typedtriggersv1alpha1.TriggerTemplateInterface {
 	return &wrapTriggersV1alpha1TriggerTemplateImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -50,6 +50,14 @@ type TriggerBindingListerExpansion interface{}
 // TriggerBindingNamespaceLister.
 type TriggerBindingNamespaceListerExpansion interface{}
 
+// TriggerInvocationListerExpansion allows custom methods to be added to
+// TriggerInvocationLister.
+type TriggerInvocationListerExpansion interface{}
+
+// TriggerInvocationNamespaceListerExpansion allows custom methods to be added to
+// TriggerInvocationNamespaceLister.
+type TriggerInvocationNamespaceListerExpansion interface{}
+
 // TriggerTemplateListerExpansion allows custom methods to be added to
 // TriggerTemplateLister.
 type TriggerTemplateListerExpansion interface{}
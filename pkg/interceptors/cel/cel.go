@@ -24,6 +24,7 @@ import (
 	"reflect"
 
 	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/template"
 
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/google/cel-go/cel"
@@ -102,20 +103,44 @@ func makeCelEnv(ns string, sl corev1lister.SecretLister) (*cel.Env, error) {
 			decls.NewVar("header", mapStrDyn),
 			decls.NewVar("extensions", mapStrDyn),
 			decls.NewVar("requestURL", decls.String),
+			// clientSubject is the subject of the certificate the client authenticated with.
+			decls.NewVar("clientSubject", decls.String),
+			// clientIP is the IP of the client that sent the event.
+			decls.NewVar("clientIP", decls.String),
+			// ce holds the attributes of a CloudEvent, and its data as ce.data, which
+			// unlike body does not have to be a JSON object.
+			decls.NewVar("ce", mapStrDyn),
 		))
 }
 
-func makeEvalContext(body []byte, h http.Header, url string, extensions map[string]interface{}) (map[string]interface{}, error) {
+func makeEvalContext(body []byte, h http.Header, url, clientSubject, clientIP string, extensions map[string]interface{}) (map[string]interface{}, error) {
+	ce := template.CloudEventAttributes(h)
 	var jsonMap map[string]interface{}
-	err := json.Unmarshal(body, &jsonMap)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse the body as JSON: %w", err)
+	if len(ce) == 0 {
+		if err := json.Unmarshal(body, &jsonMap); err != nil {
+			return nil, fmt.Errorf("failed to parse the body as JSON: %w", err)
+		}
+	} else {
+		// The data of a CloudEvent can be any JSON value, such as the string that
+		// non-JSON data is converted to, and is only the body if it is an object.
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse the CloudEvent data as JSON: %w", err)
+		}
+		ce["data"] = data
+		jsonMap, _ = data.(map[string]interface{})
+		if jsonMap == nil {
+			jsonMap = map[string]interface{}{}
+		}
 	}
 	return map[string]interface{}{
-		"body":       jsonMap,
-		"header":     h,
-		"requestURL": url,
-		"extensions": extensions,
+		"body":          jsonMap,
+		"header":        h,
+		"requestURL":    url,
+		"clientSubject": clientSubject,
+		"clientIP":      clientIP,
+		"extensions":    extensions,
+		"ce":            ce,
 	}, nil
 }
 
@@ -136,7 +161,7 @@ func (w *Interceptor) Process(ctx context.Context, r *triggersv1.InterceptorRequ
 		payload = []byte(r.Body)
 	}
 
-	evalContext, err := makeEvalContext(payload, r.Header, r.Context.EventURL, r.Extensions)
+	evalContext, err := makeEvalContext(payload, r.Header, r.Context.EventURL, r.Context.ClientSubject, r.Context.ClientIP, r.Extensions)
 	if err != nil {
 		return interceptors.Failf(codes.InvalidArgument, "error making the evaluation context: %v", err)
 	}
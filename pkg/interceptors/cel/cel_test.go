@@ -80,6 +80,24 @@ func TestInterceptor_Process(t *testing.T) {
 			Filter: "header.canonical('x-test') == 'test-value' && body.value == 'test'",
 		},
 		body: json.RawMessage(`{"value":"test"}`),
+	}, {
+		name: "CloudEvent attribute check",
+		CEL: &triggersv1.CELInterceptor{
+			Filter: "ce.type == 'com.example.push' && body.value == 'test'",
+		},
+		body: json.RawMessage(`{"value":"test"}`),
+	}, {
+		name: "CloudEvent data",
+		CEL: &triggersv1.CELInterceptor{
+			Filter: "ce.data.value == body.value",
+		},
+		body: json.RawMessage(`{"value":"test"}`),
+	}, {
+		name: "CloudEvent data that is not a JSON object",
+		CEL: &triggersv1.CELInterceptor{
+			Filter: "ce.data == 'hello' && size(body) == 0",
+		},
+		body: json.RawMessage(`"hello"`),
 	}, {
 		name: "single overlay",
 		CEL: &triggersv1.CELInterceptor{
@@ -293,6 +311,7 @@ func TestInterceptor_Process(t *testing.T) {
 					"Content-Type":   []string{"application/json"},
 					"X-Test":         []string{"test-value"},
 					"X-Secret-Token": []string{"secrettoken"},
+					"Ce-Type":        []string{"com.example.push"},
 				},
 				Extensions: tt.extensions,
 				InterceptorParams: map[string]interface{}{
@@ -365,6 +384,22 @@ func TestInterceptor_Process_Error(t *testing.T) {
 		body:     []byte(`{]`),
 		wantCode: codes.InvalidArgument,
 		wantMsg:  "invalid character ']' looking for beginning of object key string",
+	}, {
+		name: "body that is not a JSON object",
+		CEL: &triggersv1.CELInterceptor{
+			Filter: "body.value == 'test'",
+		},
+		body:     []byte(`"hello"`),
+		wantCode: codes.InvalidArgument,
+		wantMsg:  "failed to parse the body as JSON",
+	}, {
+		name: "body compared with a string",
+		CEL: &triggersv1.CELInterceptor{
+			Filter: "body == 'hello'",
+		},
+		body:     []byte(`{}`),
+		wantCode: codes.InvalidArgument,
+		wantMsg:  `expression "body == 'hello'" check failed: ERROR:.*found no matching overload`,
 	}, {
 		name: "bad overlay",
 		CEL: &triggersv1.CELInterceptor{
@@ -478,7 +513,7 @@ func TestExpressionEvaluation(t *testing.T) {
 	header := http.Header{}
 	header.Add("X-Test-Header", "value")
 	req := httptest.NewRequest(http.MethodPost, "https://example.com/testing?param=value", nil)
-	evalEnv := map[string]interface{}{"body": jsonMap, "header": header, "requestURL": req.URL.String()}
+	evalEnv := map[string]interface{}{"body": jsonMap, "header": header, "requestURL": req.URL.String(), "clientSubject": "CN=producer,O=Example", "clientIP": "192.30.252.1"}
 	tests := []struct {
 		name   string
 		expr   string
@@ -593,6 +628,16 @@ func TestExpressionEvaluation(t *testing.T) {
 			expr: "requestURL.parseURL().path",
 			want: types.String("/testing"),
 		},
+		{
+			name: "client subject",
+			expr: "clientSubject.startsWith('CN=producer,')",
+			want: types.True,
+		},
+		{
+			name: "client IP",
+			expr: "clientIP == '192.30.252.1'",
+			want: types.True,
+		},
 		{
 			name: "lower casing a string",
 			expr: "body.upperMsg.lowerAscii()",
@@ -804,7 +849,7 @@ func TestMakeEvalContextWithError(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/", nil)
 	payload := []byte(`{"tes`)
 
-	_, err := makeEvalContext(payload, req.Header, req.URL.String(), map[string]interface{}{})
+	_, err := makeEvalContext(payload, req.Header, req.URL.String(), "", "", map[string]interface{}{})
 
 	if err == nil {
 		t.Fatalf("makeEvalContext(). expected err was nil")
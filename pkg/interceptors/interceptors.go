@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"path"
 
+	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/grpc/codes"
 	"knative.dev/pkg/apis"
 
@@ -159,16 +160,32 @@ func ResolveToURL(getter InterceptorGetter, name string) (*apis.URL, error) {
 	return ic.ResolveAddress()
 }
 
+// InjectTraceContext sets the W3C Trace Context headers of req to the span in the context
+// of req, if any, so that the interceptor can continue the trace.
+func InjectTraceContext(req *http.Request) {
+	propagation.TraceContext{}.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+}
+
+// ResponseError is returned by Execute when an interceptor responds with a status other than 200.
+type ResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("interceptor response was not 200: %v", e.Body)
+}
+
 func Execute(ctx context.Context, client *http.Client, req *triggersv1beta1.InterceptorRequest, url string) (*triggersv1beta1.InterceptorResponse, error) {
 	b, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
-	// TODO: Seed context with timeouts
 	r, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(b))
 	if err != nil {
 		return nil, err
 	}
+	InjectTraceContext(r)
 	res, err := client.Do(r)
 	if err != nil {
 		return nil, err
@@ -179,8 +196,7 @@ func Execute(ctx context.Context, client *http.Client, req *triggersv1beta1.Inte
 		return nil, err
 	}
 	if res.StatusCode != http.StatusOK {
-		// TODO: error type for easier checking. wrap in status.Errorf?
-		return nil, fmt.Errorf("interceptor response was not 200: %v", string(body))
+		return nil, &ResponseError{StatusCode: res.StatusCode, Body: string(body)}
 	}
 	iresp := triggersv1beta1.InterceptorResponse{}
 	if err := json.Unmarshal(body, &iresp); err != nil {
@@ -68,6 +68,7 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 	request.URL = u
 	request.Host = u.Host
 	addInterceptorHeaders(request.Header, w.Webhook.Header)
+	interceptors.InjectTraceContext(request)
 
 	resp, err := w.HTTPClient.Do(request)
 	if err != nil {
@@ -198,13 +198,16 @@ func makeDeployment(ops ...func(d *appsv1.Deployment)) *appsv1.Deployment {
 						}, {
 							ContainerPort: int32(9000),
 							Protocol:      corev1.ProtocolTCP,
+						}, {
+							ContainerPort: int32(8081),
+							Protocol:      corev1.ProtocolTCP,
 						}},
 						LivenessProbe: &corev1.Probe{
 							Handler: corev1.Handler{
 								HTTPGet: &corev1.HTTPGetAction{
 									Path:   "/live",
 									Scheme: corev1.URISchemeHTTP,
-									Port:   intstr.FromInt(eventListenerContainerPort),
+									Port:   intstr.FromInt(8081),
 								},
 							},
 							PeriodSeconds:    int32(resources.DefaultPeriodSeconds),
@@ -213,9 +216,9 @@ func makeDeployment(ops ...func(d *appsv1.Deployment)) *appsv1.Deployment {
 						ReadinessProbe: &corev1.Probe{
 							Handler: corev1.Handler{
 								HTTPGet: &corev1.HTTPGetAction{
-									Path:   "/live",
+									Path:   "/ready",
 									Scheme: corev1.URISchemeHTTP,
-									Port:   intstr.FromInt(eventListenerContainerPort),
+									Port:   intstr.FromInt(8081),
 								},
 							},
 							PeriodSeconds:    int32(resources.DefaultPeriodSeconds),
@@ -231,6 +234,10 @@ func makeDeployment(ops ...func(d *appsv1.Deployment)) *appsv1.Deployment {
 							"--timeouthandler=" + strconv.FormatInt(resources.DefaultTimeOutHandler, 10),
 							"--is-multi-ns=false",
 							"--payload-validation=true",
+							"--max-workers=" + strconv.Itoa(resources.DefaultMaxWorkers),
+							"--max-queue-depth=" + strconv.Itoa(resources.DefaultMaxQueueDepth),
+							"--shutdown-grace-period=" + strconv.FormatInt(resources.DefaultShutdownGracePeriod, 10),
+							"--admin-port=" + "8081",
 							"--tls-cert=",
 							"--tls-key=",
 						},
@@ -263,6 +270,7 @@ func makeDeployment(ops ...func(d *appsv1.Deployment)) *appsv1.Deployment {
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: ptr.Bool(true),
 					},
+					TerminationGracePeriodSeconds: ptr.Int64(resources.DefaultShutdownGracePeriod + 5),
 				},
 			},
 		},
@@ -284,10 +292,6 @@ var withTLSConfig = func(d *appsv1.Deployment) {
 	// Replace the 2 TLS args with the right values
 	container := &d.Spec.Template.Spec.Containers[0]
 
-	// Set probes to use HTTPS
-	container.LivenessProbe.HTTPGet.Scheme = corev1.URISchemeHTTPS
-	container.ReadinessProbe.HTTPGet.Scheme = corev1.URISchemeHTTPS
-
 	// Pass keys as container args
 	for i, arg := range container.Args {
 		if arg == "--tls-key=" {
@@ -373,6 +377,9 @@ func makeWithPod(ops ...func(d *duckv1.WithPod)) *duckv1.WithPod {
 							"--timeouthandler=" + strconv.FormatInt(resources.DefaultTimeOutHandler, 10),
 							"--is-multi-ns=" + strconv.FormatBool(false),
 							"--payload-validation=" + strconv.FormatBool(true),
+							"--max-workers=" + strconv.Itoa(resources.DefaultMaxWorkers),
+							"--max-queue-depth=" + strconv.Itoa(resources.DefaultMaxQueueDepth),
+							"--shutdown-grace-period=" + strconv.FormatInt(resources.DefaultShutdownGracePeriod, 10),
 						},
 						Env: []corev1.EnvVar{{
 							Name: "K_LOGGING_CONFIG",
@@ -398,7 +405,7 @@ func makeWithPod(ops ...func(d *duckv1.WithPod)) *duckv1.WithPod {
 						ReadinessProbe: &corev1.Probe{
 							Handler: corev1.Handler{
 								HTTPGet: &corev1.HTTPGetAction{
-									Path:   "/live",
+									Path:   "/ready",
 									Scheme: corev1.URISchemeHTTP,
 								},
 							},
@@ -35,6 +35,16 @@ var (
 	DefaultPeriodSeconds = 10
 	// DefaultFailureThreshold is the FailureThreshold used by default.
 	DefaultFailureThreshold = 3
+	// DefaultMaxWorkers is the MaxWorkers used by default.
+	DefaultMaxWorkers = 100
+	// DefaultMaxQueueDepth is the MaxQueueDepth used by default.
+	DefaultMaxQueueDepth = 10000
+	// DefaultShutdownGracePeriod is the ShutdownGracePeriod used by default.
+	DefaultShutdownGracePeriod = int64(25)
+	// DefaultEnablePprof is the EnablePprof value used by default.
+	DefaultEnablePprof = false
+	// DefaultTracingCollectorAddress is the TracingCollectorAddress used by default.
+	DefaultTracingCollectorAddress = ""
 	// DefaultStaticResourceLabels are the StaticResourceLabels used by default.
 	DefaultStaticResourceLabels = map[string]string{
 		"app.kubernetes.io/managed-by": "EventListener",
@@ -63,6 +73,16 @@ type Config struct {
 	PeriodSeconds *int
 	// FailureThreshold defines the Failure Threshold for the EventListener Liveness and Readiness Probes.
 	FailureThreshold *int
+	// MaxWorkers defines the maximum number of triggers the EventListener processes concurrently.
+	MaxWorkers *int
+	// MaxQueueDepth defines the maximum number of triggers waiting to be processed by the EventListener.
+	MaxQueueDepth *int
+	// ShutdownGracePeriod defines how long in seconds the EventListener waits for triggers being processed when it shuts down.
+	ShutdownGracePeriod *int64
+	// EnablePprof defines if the EventListener serves pprof endpoints on its admin port.
+	EnablePprof *bool
+	// TracingCollectorAddress defines the address of the collector the EventListener exports traces to.
+	TracingCollectorAddress *string
 	// StaticResourceLabels is a map with all the labels that should be on all resources generated by the EventListener.
 	StaticResourceLabels map[string]string
 	// SystemNamespace is the namespace where the reconciler is deployed.
@@ -75,15 +95,20 @@ type ConfigOption func(d *Config)
 // It generates a default Config for the EventListener without any flags set and accepts functions for modification.
 func MakeConfig(ops ...ConfigOption) *Config {
 	c := &Config{
-		Image:              &DefaultImage,
-		Port:               &DefaultPort,
-		SetSecurityContext: &DefaultSetSecurityContext,
-		ReadTimeOut:        &DefaultReadTimeout,
-		WriteTimeOut:       &DefaultWriteTimeout,
-		IdleTimeOut:        &DefaultIdleTimeout,
-		TimeOutHandler:     &DefaultTimeOutHandler,
-		PeriodSeconds:      &DefaultPeriodSeconds,
-		FailureThreshold:   &DefaultFailureThreshold,
+		Image:                   &DefaultImage,
+		Port:                    &DefaultPort,
+		SetSecurityContext:      &DefaultSetSecurityContext,
+		ReadTimeOut:             &DefaultReadTimeout,
+		WriteTimeOut:            &DefaultWriteTimeout,
+		IdleTimeOut:             &DefaultIdleTimeout,
+		TimeOutHandler:          &DefaultTimeOutHandler,
+		PeriodSeconds:           &DefaultPeriodSeconds,
+		FailureThreshold:        &DefaultFailureThreshold,
+		MaxWorkers:              &DefaultMaxWorkers,
+		MaxQueueDepth:           &DefaultMaxQueueDepth,
+		EnablePprof:             &DefaultEnablePprof,
+		ShutdownGracePeriod:     &DefaultShutdownGracePeriod,
+		TracingCollectorAddress: &DefaultTracingCollectorAddress,
 
 		StaticResourceLabels: DefaultStaticResourceLabels,
 		SystemNamespace:      DefaultSystemNamespace,
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/tektoncd/triggers/pkg/apis/triggers"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
@@ -59,6 +60,9 @@ func MakeContainer(el *v1beta1.EventListener, configAcc reconcilersource.ConfigA
 			"--timeouthandler=" + strconv.FormatInt(*c.TimeOutHandler, 10),
 			"--is-multi-ns=" + strconv.FormatBool(isMultiNS),
 			"--payload-validation=" + strconv.FormatBool(payloadValidation),
+			"--max-workers=" + strconv.Itoa(*c.MaxWorkers),
+			"--max-queue-depth=" + strconv.Itoa(*c.MaxQueueDepth),
+			"--shutdown-grace-period=" + strconv.FormatInt(*c.ShutdownGracePeriod, 10),
 		},
 		Env: append(ev, []corev1.EnvVar{{
 			Name:  "NAMESPACE",
@@ -69,6 +73,38 @@ func MakeContainer(el *v1beta1.EventListener, configAcc reconcilersource.ConfigA
 		}}...),
 	}
 
+	if p := el.Spec.PayloadPolicy; p != nil {
+		if p.MaxBodySize != nil {
+			container.Args = append(container.Args, "--max-body-size="+strconv.FormatInt(*p.MaxBodySize, 10))
+		}
+		if len(p.AllowedContentTypes) > 0 {
+			container.Args = append(container.Args, "--allowed-content-types="+strings.Join(p.AllowedContentTypes, ","))
+		}
+	}
+
+	if p := el.Spec.SourceIPPolicy; p != nil {
+		container.Args = append(container.Args, "--allowed-source-ranges="+strings.Join(p.AllowedCIDRs, ","))
+		if len(p.TrustedProxies) > 0 {
+			container.Args = append(container.Args, "--trusted-proxies="+strings.Join(p.TrustedProxies, ","))
+		}
+	}
+
+	if *c.TracingCollectorAddress != "" {
+		container.Args = append(container.Args, "--tracing-collector-address="+*c.TracingCollectorAddress)
+	}
+
+	if dr := el.Spec.DryRun; dr != nil && dr.TokenSecretRef != nil {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: "DRY_RUN_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: dr.TokenSecretRef.SecretName},
+					Key:                  dr.TokenSecretRef.SecretKey,
+				},
+			},
+		})
+	}
+
 	for _, opt := range opts {
 		opt(&container)
 	}
@@ -26,16 +26,18 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
+	"knative.dev/pkg/ptr"
 )
 
 func TestContainer(t *testing.T) {
 	config := *MakeConfig()
 
 	tests := []struct {
-		name string
-		el   *v1beta1.EventListener
-		want corev1.Container
-		opts []ContainerOption
+		name   string
+		el     *v1beta1.EventListener
+		config *Config
+		want   corev1.Container
+		opts   []ContainerOption
 	}{{
 		name: "vanilla",
 		el:   makeEL(),
@@ -56,6 +58,9 @@ func TestContainer(t *testing.T) {
 				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
 				"--is-multi-ns=" + strconv.FormatBool(false),
 				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
 			},
 			Env: []corev1.EnvVar{{
 				Name: "K_LOGGING_CONFIG",
@@ -100,6 +105,9 @@ func TestContainer(t *testing.T) {
 				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
 				"--is-multi-ns=" + strconv.FormatBool(false),
 				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
 			},
 			Resources: corev1.ResourceRequirements{
 				Requests: map[corev1.ResourceName]resource.Quantity{
@@ -148,6 +156,9 @@ func TestContainer(t *testing.T) {
 				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
 				"--is-multi-ns=" + strconv.FormatBool(false),
 				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
 			},
 			Env: []corev1.EnvVar{{
 				Name:  "BAR",
@@ -176,6 +187,9 @@ func TestContainer(t *testing.T) {
 				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
 				"--is-multi-ns=" + strconv.FormatBool(true),
 				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
 			},
 			Env: []corev1.EnvVar{{
 				Name: "K_LOGGING_CONFIG",
@@ -215,6 +229,236 @@ func TestContainer(t *testing.T) {
 				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
 				"--is-multi-ns=" + strconv.FormatBool(false),
 				"--payload-validation=" + strconv.FormatBool(false),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
+			},
+			Env: []corev1.EnvVar{{
+				Name: "K_LOGGING_CONFIG",
+			}, {
+				Name: "K_METRICS_CONFIG",
+			}, {
+				Name: "K_TRACING_CONFIG",
+			}, {
+				Name:  "NAMESPACE",
+				Value: namespace,
+			}, {
+				Name:  "NAME",
+				Value: eventListenerName,
+			}},
+		},
+	}, {
+		name: "with dry run token",
+		el: makeEL(func(el *v1beta1.EventListener) {
+			el.Spec.DryRun = &v1beta1.DryRun{
+				TokenSecretRef: &v1beta1.SecretRef{
+					SecretName: "dry-run",
+					SecretKey:  "token",
+				},
+			}
+		}),
+		want: corev1.Container{
+			Name:  "event-listener",
+			Image: DefaultImage,
+			Ports: []corev1.ContainerPort{{
+				ContainerPort: int32(eventListenerContainerPort),
+				Protocol:      corev1.ProtocolTCP,
+			}},
+			Args: []string{
+				"--el-name=" + eventListenerName,
+				"--el-namespace=" + namespace,
+				"--port=" + strconv.Itoa(eventListenerContainerPort),
+				"--readtimeout=" + strconv.FormatInt(DefaultReadTimeout, 10),
+				"--writetimeout=" + strconv.FormatInt(DefaultWriteTimeout, 10),
+				"--idletimeout=" + strconv.FormatInt(DefaultIdleTimeout, 10),
+				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
+				"--is-multi-ns=" + strconv.FormatBool(false),
+				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
+			},
+			Env: []corev1.EnvVar{{
+				Name: "K_LOGGING_CONFIG",
+			}, {
+				Name: "K_METRICS_CONFIG",
+			}, {
+				Name: "K_TRACING_CONFIG",
+			}, {
+				Name:  "NAMESPACE",
+				Value: namespace,
+			}, {
+				Name:  "NAME",
+				Value: eventListenerName,
+			}, {
+				Name: "DRY_RUN_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "dry-run"},
+						Key:                  "token",
+					},
+				},
+			}},
+		},
+	}, {
+		name: "with payload policy",
+		el: makeEL(func(el *v1beta1.EventListener) {
+			el.Spec.PayloadPolicy = &v1beta1.PayloadPolicy{
+				MaxBodySize:         ptr.Int64(1024),
+				AllowedContentTypes: []string{"application/json", "application/cloudevents+json"},
+			}
+		}),
+		want: corev1.Container{
+			Name:  "event-listener",
+			Image: DefaultImage,
+			Ports: []corev1.ContainerPort{{
+				ContainerPort: int32(eventListenerContainerPort),
+				Protocol:      corev1.ProtocolTCP,
+			}},
+			Args: []string{
+				"--el-name=" + eventListenerName,
+				"--el-namespace=" + namespace,
+				"--port=" + strconv.Itoa(eventListenerContainerPort),
+				"--readtimeout=" + strconv.FormatInt(DefaultReadTimeout, 10),
+				"--writetimeout=" + strconv.FormatInt(DefaultWriteTimeout, 10),
+				"--idletimeout=" + strconv.FormatInt(DefaultIdleTimeout, 10),
+				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
+				"--is-multi-ns=" + strconv.FormatBool(false),
+				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
+				"--max-body-size=1024",
+				"--allowed-content-types=application/json,application/cloudevents+json",
+			},
+			Env: []corev1.EnvVar{{
+				Name: "K_LOGGING_CONFIG",
+			}, {
+				Name: "K_METRICS_CONFIG",
+			}, {
+				Name: "K_TRACING_CONFIG",
+			}, {
+				Name:  "NAMESPACE",
+				Value: namespace,
+			}, {
+				Name:  "NAME",
+				Value: eventListenerName,
+			}},
+		},
+	}, {
+		name: "with tracing collector",
+		el:   makeEL(),
+		config: MakeConfig(func(c *Config) {
+			c.TracingCollectorAddress = ptr.String("otel-collector.observability:4318")
+		}),
+		want: corev1.Container{
+			Name:  "event-listener",
+			Image: DefaultImage,
+			Ports: []corev1.ContainerPort{{
+				ContainerPort: int32(eventListenerContainerPort),
+				Protocol:      corev1.ProtocolTCP,
+			}},
+			Args: []string{
+				"--el-name=" + eventListenerName,
+				"--el-namespace=" + namespace,
+				"--port=" + strconv.Itoa(eventListenerContainerPort),
+				"--readtimeout=" + strconv.FormatInt(DefaultReadTimeout, 10),
+				"--writetimeout=" + strconv.FormatInt(DefaultWriteTimeout, 10),
+				"--idletimeout=" + strconv.FormatInt(DefaultIdleTimeout, 10),
+				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
+				"--is-multi-ns=" + strconv.FormatBool(false),
+				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
+				"--tracing-collector-address=otel-collector.observability:4318",
+			},
+			Env: []corev1.EnvVar{{
+				Name: "K_LOGGING_CONFIG",
+			}, {
+				Name: "K_METRICS_CONFIG",
+			}, {
+				Name: "K_TRACING_CONFIG",
+			}, {
+				Name:  "NAMESPACE",
+				Value: namespace,
+			}, {
+				Name:  "NAME",
+				Value: eventListenerName,
+			}},
+		},
+	}, {
+		name: "with source IP policy",
+		el: makeEL(func(el *v1beta1.EventListener) {
+			el.Spec.SourceIPPolicy = &v1beta1.SourceIPPolicy{
+				AllowedCIDRs:   []string{"192.30.252.0/22", "185.199.108.0/22"},
+				TrustedProxies: []string{"10.0.0.0/8"},
+			}
+		}),
+		want: corev1.Container{
+			Name:  "event-listener",
+			Image: DefaultImage,
+			Ports: []corev1.ContainerPort{{
+				ContainerPort: int32(eventListenerContainerPort),
+				Protocol:      corev1.ProtocolTCP,
+			}},
+			Args: []string{
+				"--el-name=" + eventListenerName,
+				"--el-namespace=" + namespace,
+				"--port=" + strconv.Itoa(eventListenerContainerPort),
+				"--readtimeout=" + strconv.FormatInt(DefaultReadTimeout, 10),
+				"--writetimeout=" + strconv.FormatInt(DefaultWriteTimeout, 10),
+				"--idletimeout=" + strconv.FormatInt(DefaultIdleTimeout, 10),
+				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
+				"--is-multi-ns=" + strconv.FormatBool(false),
+				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
+				"--allowed-source-ranges=192.30.252.0/22,185.199.108.0/22",
+				"--trusted-proxies=10.0.0.0/8",
+			},
+			Env: []corev1.EnvVar{{
+				Name: "K_LOGGING_CONFIG",
+			}, {
+				Name: "K_METRICS_CONFIG",
+			}, {
+				Name: "K_TRACING_CONFIG",
+			}, {
+				Name:  "NAMESPACE",
+				Value: namespace,
+			}, {
+				Name:  "NAME",
+				Value: eventListenerName,
+			}},
+		},
+	}, {
+		name: "with tracing collector",
+		el:   makeEL(),
+		config: MakeConfig(func(c *Config) {
+			c.TracingCollectorAddress = ptr.String("otel-collector.observability:4318")
+		}),
+		want: corev1.Container{
+			Name:  "event-listener",
+			Image: DefaultImage,
+			Ports: []corev1.ContainerPort{{
+				ContainerPort: int32(eventListenerContainerPort),
+				Protocol:      corev1.ProtocolTCP,
+			}},
+			Args: []string{
+				"--el-name=" + eventListenerName,
+				"--el-namespace=" + namespace,
+				"--port=" + strconv.Itoa(eventListenerContainerPort),
+				"--readtimeout=" + strconv.FormatInt(DefaultReadTimeout, 10),
+				"--writetimeout=" + strconv.FormatInt(DefaultWriteTimeout, 10),
+				"--idletimeout=" + strconv.FormatInt(DefaultIdleTimeout, 10),
+				"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
+				"--is-multi-ns=" + strconv.FormatBool(false),
+				"--payload-validation=" + strconv.FormatBool(true),
+				"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+				"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+				"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
+				"--tracing-collector-address=otel-collector.observability:4318",
 			},
 			Env: []corev1.EnvVar{{
 				Name: "K_LOGGING_CONFIG",
@@ -234,7 +478,11 @@ func TestContainer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := MakeContainer(tt.el, &reconcilersource.EmptyVarsGenerator{}, config, tt.opts...)
+			c := config
+			if tt.config != nil {
+				c = *tt.config
+			}
+			got := MakeContainer(tt.el, &reconcilersource.EmptyVarsGenerator{}, c, tt.opts...)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("MakeContainer() did not return expected. -want, +got: %s", diff)
 			}
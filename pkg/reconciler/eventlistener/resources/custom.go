@@ -68,10 +68,12 @@ func MakeCustomObject(ctx context.Context, el *v1beta1.EventListener, configAcc
 			Value: os.Getenv("METRICS_PROMETHEUS_PORT"),
 		})
 
+		// Knative Services can only probe the port that serves events, so the
+		// probes are served on it instead of on an admin port.
 		c.ReadinessProbe = &corev1.Probe{
 			Handler: corev1.Handler{
 				HTTPGet: &corev1.HTTPGetAction{
-					Path:   "/live",
+					Path:   "/ready",
 					Scheme: corev1.URISchemeHTTP,
 				},
 			},
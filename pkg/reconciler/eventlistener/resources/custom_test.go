@@ -69,6 +69,9 @@ func TestCustomObject(t *testing.T) {
 		"--timeouthandler=" + strconv.FormatInt(DefaultTimeOutHandler, 10),
 		"--is-multi-ns=" + strconv.FormatBool(false),
 		"--payload-validation=" + strconv.FormatBool(true),
+		"--max-workers=" + strconv.Itoa(DefaultMaxWorkers),
+		"--max-queue-depth=" + strconv.Itoa(DefaultMaxQueueDepth),
+		"--shutdown-grace-period=" + strconv.FormatInt(DefaultShutdownGracePeriod, 10),
 	}
 
 	containerEnv := []interface{}{
@@ -146,7 +149,7 @@ func TestCustomObject(t *testing.T) {
 									"resources": map[string]interface{}{},
 									"readinessProbe": map[string]interface{}{
 										"httpGet": map[string]interface{}{
-											"path":   "/live",
+											"path":   "/ready",
 											"port":   int64(0),
 											"scheme": "HTTP",
 										},
@@ -213,7 +216,7 @@ func TestCustomObject(t *testing.T) {
 									"resources": map[string]interface{}{},
 									"readinessProbe": map[string]interface{}{
 										"httpGet": map[string]interface{}{
-											"path":   "/live",
+											"path":   "/ready",
 											"port":   int64(0),
 											"scheme": "HTTP",
 										},
@@ -280,7 +283,7 @@ func TestCustomObject(t *testing.T) {
 									},
 									"readinessProbe": map[string]interface{}{
 										"httpGet": map[string]interface{}{
-											"path":   "/live",
+											"path":   "/ready",
 											"port":   int64(0),
 											"scheme": "HTTP",
 										},
@@ -430,7 +433,7 @@ func TestUpdateCustomObject(t *testing.T) {
 									},
 									"readinessProbe": map[string]interface{}{
 										"httpGet": map[string]interface{}{
-											"path":   "/live",
+											"path":   "/ready",
 											"port":   int64(0),
 											"scheme": "HTTP",
 										},
@@ -33,6 +33,14 @@ import (
 
 const (
 	TriggersMetricsDomain = "tekton.dev/triggers"
+
+	// terminationGracePeriodMargin is the time in seconds the EventListener Pod is given
+	// to exit after the shutdown grace period of the EventListener expires.
+	terminationGracePeriodMargin = 5
+
+	// clientCAVolumeName is the name of the volume of the CA bundle that client certificates are verified with.
+	clientCAVolumeName = "client-ca"
+	clientCAMountPath  = "/etc/triggers/client-ca"
 )
 
 var (
@@ -48,7 +56,7 @@ func MakeDeployment(ctx context.Context, el *v1beta1.EventListener, configAcc re
 		return nil, err
 	}
 
-	container := MakeContainer(el, configAcc, c, opt, addCertsForSecureConnection(c))
+	container := MakeContainer(el, configAcc, c, opt, addCertsForSecureConnection(), addClientAuth(el))
 
 	filteredLabels := FilterLabels(ctx, el.Labels)
 
@@ -75,6 +83,17 @@ func MakeDeployment(ctx context.Context, el *v1beta1.EventListener, configAcc re
 		}
 	}
 
+	if ca := el.Spec.ClientAuth; ca != nil && ca.CABundleSecretRef != nil {
+		vol = append(vol, corev1.Volume{
+			Name: clientCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ca.CABundleSecretRef.SecretName,
+				},
+			},
+		})
+	}
+
 	if el.Spec.Resources.KubernetesResource != nil {
 		if el.Spec.Resources.KubernetesResource.Replicas != nil {
 			replicas = el.Spec.Resources.KubernetesResource.Replicas
@@ -110,12 +129,13 @@ func MakeDeployment(ctx context.Context, el *v1beta1.EventListener, configAcc re
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					Tolerations:        tolerations,
-					NodeSelector:       nodeSelector,
-					ServiceAccountName: serviceAccountName,
-					Containers:         []corev1.Container{container},
-					Volumes:            vol,
-					SecurityContext:    &securityContext,
+					Tolerations:                   tolerations,
+					NodeSelector:                  nodeSelector,
+					ServiceAccountName:            serviceAccountName,
+					Containers:                    []corev1.Container{container},
+					Volumes:                       vol,
+					SecurityContext:               &securityContext,
+					TerminationGracePeriodSeconds: ptr.Int64(*c.ShutdownGracePeriod + terminationGracePeriodMargin),
 				},
 			},
 		},
@@ -141,8 +161,40 @@ func addDeploymentBits(el *v1beta1.EventListener, c Config) (ContainerOption, er
 		container.Ports = append(container.Ports, corev1.ContainerPort{
 			ContainerPort: int32(metricsPort),
 			Protocol:      corev1.ProtocolTCP,
+		}, corev1.ContainerPort{
+			ContainerPort: int32(eventListenerAdminPort),
+			Protocol:      corev1.ProtocolTCP,
 		})
 
+		// The probes are served on the admin port, which is always plain HTTP,
+		// so that they are not exposed along with events.
+		container.Args = append(container.Args, "--admin-port="+strconv.Itoa(eventListenerAdminPort))
+		if *c.EnablePprof {
+			container.Args = append(container.Args, "--enable-pprof=true")
+		}
+		container.LivenessProbe = &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/live",
+					Scheme: corev1.URISchemeHTTP,
+					Port:   intstr.FromInt(eventListenerAdminPort),
+				},
+			},
+			PeriodSeconds:    int32(*c.PeriodSeconds),
+			FailureThreshold: int32(*c.FailureThreshold),
+		}
+		container.ReadinessProbe = &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/ready",
+					Scheme: corev1.URISchemeHTTP,
+					Port:   intstr.FromInt(eventListenerAdminPort),
+				},
+			},
+			PeriodSeconds:    int32(*c.PeriodSeconds),
+			FailureThreshold: int32(*c.FailureThreshold),
+		}
+
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name: "SYSTEM_NAMESPACE",
 			ValueFrom: &corev1.EnvVarSource{
@@ -158,14 +210,13 @@ func addDeploymentBits(el *v1beta1.EventListener, c Config) (ContainerOption, er
 	}, nil
 }
 
-func addCertsForSecureConnection(c Config) ContainerOption {
+func addCertsForSecureConnection() ContainerOption {
 	return func(container *corev1.Container) {
 		var elCert, elKey string
 		certEnv := map[string]*corev1.EnvVarSource{}
 		for i := range container.Env {
 			certEnv[container.Env[i].Name] = container.Env[i].ValueFrom
 		}
-		var scheme corev1.URIScheme
 		if v, ok := certEnv["TLS_CERT"]; ok {
 			elCert = "/etc/triggers/tls/" + v.SecretKeyRef.Key
 		} else {
@@ -178,37 +229,35 @@ func addCertsForSecureConnection(c Config) ContainerOption {
 		}
 
 		if elCert != "" && elKey != "" {
-			scheme = corev1.URISchemeHTTPS
 			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 				Name:      "https-connection",
 				ReadOnly:  true,
 				MountPath: "/etc/triggers/tls",
 			})
-		} else {
-			scheme = corev1.URISchemeHTTP
 		}
-		container.LivenessProbe = &corev1.Probe{
-			Handler: corev1.Handler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   "/live",
-					Scheme: scheme,
-					Port:   intstr.FromInt(eventListenerContainerPort),
-				},
-			},
-			PeriodSeconds:    int32(*c.PeriodSeconds),
-			FailureThreshold: int32(*c.FailureThreshold),
+		container.Args = append(container.Args, "--tls-cert="+elCert, "--tls-key="+elKey)
+	}
+}
+
+// addClientAuth configures the EventListener to verify the certificates of its clients
+// with the CA bundle of el.
+func addClientAuth(el *v1beta1.EventListener) ContainerOption {
+	return func(container *corev1.Container) {
+		ca := el.Spec.ClientAuth
+		if ca == nil || ca.CABundleSecretRef == nil {
+			return
 		}
-		container.ReadinessProbe = &corev1.Probe{
-			Handler: corev1.Handler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   "/live",
-					Scheme: scheme,
-					Port:   intstr.FromInt(eventListenerContainerPort),
-				},
-			},
-			PeriodSeconds:    int32(*c.PeriodSeconds),
-			FailureThreshold: int32(*c.FailureThreshold),
+		mode := ca.Mode
+		if mode == "" {
+			mode = v1beta1.ClientAuthRequired
 		}
-		container.Args = append(container.Args, "--tls-cert="+elCert, "--tls-key="+elKey)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      clientCAVolumeName,
+			ReadOnly:  true,
+			MountPath: clientCAMountPath,
+		})
+		container.Args = append(container.Args,
+			"--tls-client-ca="+clientCAMountPath+"/"+ca.CABundleSecretRef.SecretKey,
+			"--tls-client-auth="+string(mode))
 	}
 }
@@ -76,9 +76,10 @@ func TestDeployment(t *testing.T) {
 						Containers: []corev1.Container{
 							MakeContainer(makeEL(), &reconcilersource.EmptyVarsGenerator{}, config,
 								mustAddDeployBits(t, makeEL(), config),
-								addCertsForSecureConnection(config)),
+								addCertsForSecureConnection()),
 						},
-						SecurityContext: &strongerSecurityPolicy,
+						SecurityContext:               &strongerSecurityPolicy,
+						TerminationGracePeriodSeconds: ptr.Int64(DefaultShutdownGracePeriod + terminationGracePeriodMargin),
 					},
 				},
 			},
@@ -111,9 +112,10 @@ func TestDeployment(t *testing.T) {
 						Containers: []corev1.Container{
 							MakeContainer(makeEL(), &reconcilersource.EmptyVarsGenerator{}, config,
 								mustAddDeployBits(t, makeEL(), config),
-								addCertsForSecureConnection(config)),
+								addCertsForSecureConnection()),
 						},
-						SecurityContext: &strongerSecurityPolicy,
+						SecurityContext:               &strongerSecurityPolicy,
+						TerminationGracePeriodSeconds: ptr.Int64(DefaultShutdownGracePeriod + terminationGracePeriodMargin),
 					},
 				},
 			},
@@ -154,9 +156,10 @@ func TestDeployment(t *testing.T) {
 						Containers: []corev1.Container{
 							MakeContainer(makeEL(), &reconcilersource.EmptyVarsGenerator{}, config,
 								mustAddDeployBits(t, makeEL(), config),
-								addCertsForSecureConnection(config)),
+								addCertsForSecureConnection()),
 						},
-						SecurityContext: &strongerSecurityPolicy,
+						SecurityContext:               &strongerSecurityPolicy,
+						TerminationGracePeriodSeconds: ptr.Int64(DefaultShutdownGracePeriod + terminationGracePeriodMargin),
 						Tolerations: []corev1.Toleration{{
 							Key:   "foo",
 							Value: "bar",
@@ -200,9 +203,10 @@ func TestDeployment(t *testing.T) {
 						Containers: []corev1.Container{
 							MakeContainer(makeEL(), &reconcilersource.EmptyVarsGenerator{}, config,
 								mustAddDeployBits(t, makeEL(), config),
-								addCertsForSecureConnection(config)),
+								addCertsForSecureConnection()),
 						},
-						SecurityContext: &strongerSecurityPolicy,
+						SecurityContext:               &strongerSecurityPolicy,
+						TerminationGracePeriodSeconds: ptr.Int64(DefaultShutdownGracePeriod + terminationGracePeriodMargin),
 						NodeSelector: map[string]string{
 							"foo": "bar",
 						},
@@ -243,9 +247,10 @@ func TestDeployment(t *testing.T) {
 						Containers: []corev1.Container{
 							MakeContainer(makeEL(), &reconcilersource.EmptyVarsGenerator{}, config,
 								mustAddDeployBits(t, makeEL(), config),
-								addCertsForSecureConnection(config)),
+								addCertsForSecureConnection()),
 						},
-						SecurityContext: &strongerSecurityPolicy,
+						SecurityContext:               &strongerSecurityPolicy,
+						TerminationGracePeriodSeconds: ptr.Int64(DefaultShutdownGracePeriod + terminationGracePeriodMargin),
 					},
 				},
 			},
@@ -273,7 +278,7 @@ func TestDeployment(t *testing.T) {
 						Containers: []corev1.Container{
 							MakeContainer(makeEL(withTLSEnvFrom("Bill")), &reconcilersource.EmptyVarsGenerator{}, config,
 								mustAddDeployBits(t, makeEL(withTLSEnvFrom("Bill")), config),
-								addCertsForSecureConnection(config)),
+								addCertsForSecureConnection()),
 						},
 						Volumes: []corev1.Volume{{
 							Name: "https-connection",
@@ -283,7 +288,8 @@ func TestDeployment(t *testing.T) {
 								},
 							},
 						}},
-						SecurityContext: &strongerSecurityPolicy,
+						SecurityContext:               &strongerSecurityPolicy,
+						TerminationGracePeriodSeconds: ptr.Int64(DefaultShutdownGracePeriod + terminationGracePeriodMargin),
 					},
 				},
 			},
@@ -350,6 +356,99 @@ func withTLSEnvFrom(name string) func(*v1beta1.EventListener) {
 	}
 }
 
+func TestDeployment_ClientAuth(t *testing.T) {
+	if err := os.Setenv("METRICS_PROMETHEUS_PORT", "9000"); err != nil {
+		t.Fatal(err)
+	}
+	el := makeEL(withTLSEnvFrom("Bill"), func(el *v1beta1.EventListener) {
+		el.Spec.ClientAuth = &v1beta1.ClientAuth{
+			CABundleSecretRef: &v1beta1.SecretRef{SecretName: "client-ca-secret", SecretKey: "ca.crt"},
+		}
+	})
+
+	got, err := MakeDeployment(context.Background(), el, &reconcilersource.EmptyVarsGenerator{}, *MakeConfig())
+	if err != nil {
+		t.Fatalf("MakeDeployment() = %v", err)
+	}
+	wantVolumes := []corev1.Volume{{
+		Name: "https-connection",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "Bill"},
+		},
+	}, {
+		Name: "client-ca",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "client-ca-secret"},
+		},
+	}}
+	if diff := cmp.Diff(wantVolumes, got.Spec.Template.Spec.Volumes); diff != "" {
+		t.Errorf("Volumes did not match. -want, +got: %s", diff)
+	}
+	container := got.Spec.Template.Spec.Containers[0]
+	wantMounts := []corev1.VolumeMount{{
+		Name:      "https-connection",
+		ReadOnly:  true,
+		MountPath: "/etc/triggers/tls",
+	}, {
+		Name:      "client-ca",
+		ReadOnly:  true,
+		MountPath: "/etc/triggers/client-ca",
+	}}
+	if diff := cmp.Diff(wantMounts, container.VolumeMounts); diff != "" {
+		t.Errorf("VolumeMounts did not match. -want, +got: %s", diff)
+	}
+	wantArgs := []string{
+		"--tls-cert=/etc/triggers/tls/cert",
+		"--tls-key=/etc/triggers/tls/key",
+		"--tls-client-ca=/etc/triggers/client-ca/ca.crt",
+		"--tls-client-auth=Required",
+	}
+	if diff := cmp.Diff(wantArgs, container.Args[len(container.Args)-4:]); diff != "" {
+		t.Errorf("Args did not match. -want, +got: %s", diff)
+	}
+}
+
+func TestDeployment_AdminPort(t *testing.T) {
+	if err := os.Setenv("METRICS_PROMETHEUS_PORT", "9000"); err != nil {
+		t.Fatal(err)
+	}
+	config := *MakeConfig(func(c *Config) {
+		c.EnablePprof = ptr.Bool(true)
+	})
+
+	got, err := MakeDeployment(context.Background(), makeEL(), &reconcilersource.EmptyVarsGenerator{}, config)
+	if err != nil {
+		t.Fatalf("MakeDeployment() = %v", err)
+	}
+	container := got.Spec.Template.Spec.Containers[0]
+
+	wantPorts := []corev1.ContainerPort{{
+		ContainerPort: int32(eventListenerContainerPort),
+		Protocol:      corev1.ProtocolTCP,
+	}, {
+		ContainerPort: int32(eventListenerMetricsPort),
+		Protocol:      corev1.ProtocolTCP,
+	}, {
+		ContainerPort: int32(eventListenerAdminPort),
+		Protocol:      corev1.ProtocolTCP,
+	}}
+	if diff := cmp.Diff(wantPorts, container.Ports); diff != "" {
+		t.Errorf("Ports did not match. -want, +got: %s", diff)
+	}
+	wantArgs := []string{"--admin-port=8081", "--enable-pprof=true"}
+	if diff := cmp.Diff(wantArgs, container.Args[len(container.Args)-4:len(container.Args)-2]); diff != "" {
+		t.Errorf("Args did not match. -want, +got: %s", diff)
+	}
+	for _, p := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe} {
+		if p.HTTPGet.Port.IntValue() != eventListenerAdminPort || p.HTTPGet.Scheme != corev1.URISchemeHTTP {
+			t.Errorf("probe %s is not served over HTTP on the admin port: %+v", p.HTTPGet.Path, p.HTTPGet)
+		}
+	}
+	if container.ReadinessProbe.HTTPGet.Path != "/ready" {
+		t.Errorf("readiness probe path = %s, want /ready", container.ReadinessProbe.HTTPGet.Path)
+	}
+}
+
 func mustAddDeployBits(t *testing.T, el *v1beta1.EventListener, c Config) ContainerOption {
 	opt, err := addDeploymentBits(el, c)
 	if err != nil {
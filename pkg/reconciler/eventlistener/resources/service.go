@@ -37,6 +37,9 @@ const (
 	eventListenerContainerPort = 8080
 	// eventListenerMetricsPort defines metrics port for EventListener Service
 	eventListenerMetricsPort = 9000
+	// eventListenerAdminPort defines the port of the liveness, readiness, pprof and metrics
+	// endpoints of the EventListener Container
+	eventListenerAdminPort = 8081
 )
 
 var metricsPort = corev1.ServicePort{
@@ -21,78 +21,197 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	"k8s.io/client-go/dynamic"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	discoveryclient "k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/retry"
 )
 
-// findAPIResource returns the APIResource definition using the discovery client c.
-func findAPIResource(apiVersion, kind string, c discoveryclient.ServerResourcesInterface) (*metav1.APIResource, error) {
-	resourceList, err := c.ServerResourcesForGroupVersion(apiVersion)
+// FieldManager is the field manager of the resources that Triggers apply or patch.
+const FieldManager = "tekton-triggers"
+
+// tracerName is the name of the tracer the creation of resources is recorded with.
+const tracerName = "github.com/tektoncd/triggers/pkg/resources"
+
+// readyTimeout is how long the creation of resources waits for a resource whose resource
+// template asks to wait for it to be ready.
+var readyTimeout = 5 * time.Minute
+
+// readyPollInterval is how often a resource is checked until it is ready.
+const readyPollInterval = time.Second
+
+// rediscoveryInterval is the minimum time between two discoveries of the resources served
+// by the API server for the same kind that was not found.
+var rediscoveryInterval = time.Minute
+
+// NewRESTMapper returns a RESTMapper that maps kinds to resources with the discovery
+// client c. The resources served by the API server are discovered when the first kind
+// is mapped, and cached until a kind that they do not include is mapped. A kind that
+// is not found, for example because it is mistyped, causes a discovery at most once
+// every rediscoveryInterval.
+func NewRESTMapper(c discoveryclient.DiscoveryInterface) meta.RESTMapper {
+	return &rediscoveringRESTMapper{
+		DeferredDiscoveryRESTMapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(c)),
+		rediscovered:                map[schema.GroupVersionKind]time.Time{},
+	}
+}
+
+// rediscoveringRESTMapper is a RESTMapper that discovers the resources served by the API
+// server again when a kind is not found.
+type rediscoveringRESTMapper struct {
+	*restmapper.DeferredDiscoveryRESTMapper
+
+	mu sync.Mutex
+	// rediscovered maps the kinds that were not found to the last time they caused a discovery.
+	rediscovered map[schema.GroupVersionKind]time.Time
+}
+
+// rediscover discards the discovered resources so that they are discovered again, unless
+// gvk already caused a discovery within rediscoveryInterval. It returns true if the
+// resources were discarded.
+func (m *rediscoveringRESTMapper) rediscover(gvk schema.GroupVersionKind, now time.Time) bool {
+	m.mu.Lock()
+	if last, ok := m.rediscovered[gvk]; ok && now.Sub(last) < rediscoveryInterval {
+		m.mu.Unlock()
+		return false
+	}
+	m.rediscovered[gvk] = now
+	m.mu.Unlock()
+	m.Reset()
+	return true
+}
+
+// findAPIResource returns the APIResource definition using the RESTMapper mapper.
+func findAPIResource(apiVersion, kind string, mapper meta.RESTMapper) (*metav1.APIResource, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
-		return nil, fmt.Errorf("error getting kubernetes server resources for apiVersion %s: %s", apiVersion, err)
+		return nil, fmt.Errorf("error parsing parsing GroupVersion: %v", err)
 	}
-	for i := range resourceList.APIResources {
-		r := &resourceList.APIResources[i]
-		if r.Kind != kind {
-			continue
+	gk := schema.GroupKind{Group: gv.Group, Kind: kind}
+	mapping, err := mapper.RESTMapping(gk, gv.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind might have been installed since the resources were discovered.
+		if m, ok := mapper.(*rediscoveringRESTMapper); ok && m.rediscover(gv.WithKind(kind), time.Now()) {
+			mapping, err = mapper.RESTMapping(gk, gv.Version)
 		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error could not find resource with apiVersion %s and kind %s: %w", apiVersion, kind, err)
+	}
+	return &metav1.APIResource{
+		Name:       mapping.Resource.Resource,
+		Namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+		Group:      mapping.Resource.Group,
+		Version:    mapping.Resource.Version,
+		Kind:       mapping.GroupVersionKind.Kind,
+	}, nil
+}
 
-		// Resolve GroupVersion from parent list to have consistent resource identifiers.
-		if r.Version == "" || r.Group == "" {
-			gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing parsing GroupVersion: %v", err)
-			}
-			r.Group = gv.Group
-			r.Version = gv.Version
-		}
-		return r, nil
+// GroupVersionResource returns the resource of the kind with apiVersion, and whether it
+// is namespaced.
+func GroupVersionResource(apiVersion, kind string, mapper meta.RESTMapper) (schema.GroupVersionResource, bool, error) {
+	apiResource, err := findAPIResource(apiVersion, kind, mapper)
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
 	}
-	return nil, fmt.Errorf("error could not find resource with apiVersion %s and kind %s", apiVersion, kind)
+	gvr := schema.GroupVersionResource{
+		Group:    apiResource.Group,
+		Version:  apiResource.Version,
+		Resource: apiResource.Name,
+	}
+	return gvr, apiResource.Namespaced, nil
 }
 
 // Create uses the kubeClient to create the resource defined in the
-// TriggerResourceTemplate and returns any errors with this process
-func Create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID, elName, elNamespace string, c discoveryclient.ServerResourcesInterface, dc dynamic.Interface) error {
+// TriggerResourceTemplate and returns the created resource or any errors with this process.
+// A resource that was created but did not become ready is returned with the error.
+func Create(ctx context.Context, logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID, elName, triggerNamespace string, mapper meta.RESTMapper, dc dynamic.Interface) (created *unstructured.Unstructured, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "CreateResource")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	// Assume the TriggerResourceTemplate is valid (it has an apiVersion and Kind)
 	data := new(unstructured.Unstructured)
 	if err := data.UnmarshalJSON(rt); err != nil {
-		return fmt.Errorf("couldn't unmarshal json from the TriggerTemplate: %v", err)
+		return nil, fmt.Errorf("couldn't unmarshal json from the TriggerTemplate: %v", err)
+	}
+	span.SetAttributes(
+		attribute.String("kind", data.GetKind()),
+		attribute.String("name", data.GetName()),
+	)
+	// Link the resource to the trace, so that the traces of the resource can be related to it.
+	// Spans are only recorded when traces are exported, so resources are not annotated
+	// with the IDs of traces that are never exported.
+	if sc := span.SpanContext(); span.IsRecording() && sc.IsSampled() {
+		addAnnotation(data, triggers.TraceIDAnnotation, sc.TraceID().String())
 	}
 
-	data, err := addLabels(data, map[string]string{
-		triggers.EventListenerLabelKey: elName,
-		triggers.EventIDLabelKey:       eventID,
-		triggers.TriggerLabelKey:       triggerName,
-	})
+	policy := triggersv1.CreatePolicyCreate
+	if p, ok := data.GetAnnotations()[triggers.CreatePolicyAnnotation]; ok {
+		policy = triggersv1.CreatePolicy(p)
+		removeAnnotation(data, triggers.CreatePolicyAnnotation)
+	}
+
+	labels := map[string]string{
+		triggers.EventListenerLabelKey:    elName,
+		triggers.EventIDLabelKey:          eventID,
+		triggers.TriggerLabelKey:          triggerName,
+		triggers.TriggerNamespaceLabelKey: triggerNamespace,
+	}
+	if policy == triggersv1.CreatePolicyCreate {
+		labels[triggers.CreatedByEventLabelKey] = "true"
+	}
+	data, err = addLabels(data, labels)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	waitForReady := data.GetAnnotations()[triggers.WaitForReadyAnnotation] == "true"
+	// The hints that order the resources of a template are not part of the resources.
+	for _, key := range []string{triggers.ResourceNameAnnotation, triggers.DependsOnAnnotation, triggers.WaitForReadyAnnotation} {
+		removeAnnotation(data, key)
 	}
 
 	namespace := data.GetNamespace()
-	// Default the resource creation to the EventListenerNamespace if not found in the resource template
+	// Default the resource creation to the namespace of the Trigger if not found in the resource template
 	if namespace == "" {
-		namespace = elNamespace
+		namespace = triggerNamespace
 	}
 
 	// Resolve resource kind to the underlying API Resource type.
-	apiResource, err := findAPIResource(data.GetAPIVersion(), data.GetKind(), c)
+	apiResource, err := findAPIResource(data.GetAPIVersion(), data.GetKind(), mapper)
 	if err != nil {
-		return fmt.Errorf("couldn't find API resource for json: %v", err)
+		return nil, fmt.Errorf("couldn't find API resource for json: %v", err)
 	}
 
 	name := data.GetName()
 	if name == "" {
+		if policy != triggersv1.CreatePolicyCreate {
+			return nil, fmt.Errorf("resource of kind %s with create policy %s must have a name", data.GetKind(), policy)
+		}
 		name = data.GetGenerateName()
 	}
 	logger.Infof("Generating resource: kind: %s, name: %s", apiResource, name)
@@ -103,17 +222,176 @@ func Create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID,
 		Resource: apiResource.Name,
 	}
 
-	logger.Infof("For event ID %q creating resource %v", eventID, gvr)
+	logger.Infof("For event ID %q creating resource %v with policy %s", eventID, gvr, policy)
 
-	if _, err := dc.Resource(gvr).Namespace(namespace).Create(context.Background(), data, metav1.CreateOptions{}); err != nil {
+	var client dynamic.ResourceInterface = dc.Resource(gvr)
+	if apiResource.Namespaced {
+		client = dc.Resource(gvr).Namespace(namespace)
+	} else {
+		data.SetNamespace("")
+	}
+	switch policy {
+	case triggersv1.CreatePolicyCreate:
+		created, err = client.Create(ctx, data, metav1.CreateOptions{})
+	case triggersv1.CreatePolicyCreateOrUpdate:
+		created, err = createOrUpdate(ctx, client, data)
+	case triggersv1.CreatePolicyApply:
+		created, err = apply(ctx, client, data)
+	case triggersv1.CreatePolicyPatch:
+		created, err = patch(ctx, client, data)
+	default:
+		return nil, fmt.Errorf("unknown create policy %q of resource %s", policy, name)
+	}
+	if err != nil {
 		if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
-			return err
+			return nil, err
+		}
+		return nil, fmt.Errorf("couldn't create resource with group version kind %q: %w", gvr, err)
+	}
+	if waitForReady {
+		logger.Infof("For event ID %q waiting for resource %v %s to be ready", eventID, gvr, created.GetName())
+		ready, err := waitUntilReady(ctx, client, created)
+		if err != nil {
+			// The resource was created, so the error is not wrapped to not be retried.
+			return created, fmt.Errorf("resource %s was created but is not ready: %v", name, err)
 		}
-		return fmt.Errorf("couldn't create resource with group version kind %q: %v", gvr, err)
+		created = ready
+	}
+	return created, nil
+}
+
+// Delete deletes the resource us, and lets the garbage collector delete the resources
+// it owns. Resources that were already deleted are ignored.
+func Delete(ctx context.Context, us *unstructured.Unstructured, mapper meta.RESTMapper, dc dynamic.Interface) error {
+	gvr, namespaced, err := GroupVersionResource(us.GetAPIVersion(), us.GetKind(), mapper)
+	if err != nil {
+		return fmt.Errorf("couldn't find API resource for %s %s: %v", us.GetKind(), us.GetName(), err)
+	}
+	var client dynamic.ResourceInterface = dc.Resource(gvr)
+	if namespaced {
+		client = dc.Resource(gvr).Namespace(us.GetNamespace())
+	}
+	propagation := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	if uid := us.GetUID(); uid != "" {
+		// A resource that was recreated with the same name since is not deleted.
+		opts.Preconditions = metav1.NewUIDPreconditions(string(uid))
+	}
+	if err := client.Delete(ctx, us.GetName(), opts); err != nil && !kerrors.IsNotFound(err) {
+		return err
 	}
 	return nil
 }
 
+// waitUntilReady waits until the resource us is ready, and returns its latest version.
+// It fails if the resource reports that it failed, or is not ready in readyTimeout.
+func waitUntilReady(ctx context.Context, client dynamic.ResourceInterface, us *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	latest := us
+	err := wait.PollImmediateUntil(readyPollInterval, func() (bool, error) {
+		if latest == nil {
+			var err error
+			if latest, err = client.Get(ctx, us.GetName(), metav1.GetOptions{}); err != nil {
+				return false, err
+			}
+		}
+		ready, err := isReady(latest)
+		if !ready {
+			latest = nil
+		}
+		return ready, err
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return nil, fmt.Errorf("timed out after %s", readyTimeout)
+	}
+	return latest, err
+}
+
+// isReady returns true if the resource us is ready, and an error if it failed. Resources
+// are ready when their Ready, Available or Succeeded condition is true, or their phase is
+// Bound, Active, Running or Succeeded. Resources without a status, such as ConfigMaps,
+// are ready once they are created.
+func isReady(us *unstructured.Unstructured) (bool, error) {
+	status, ok := us.Object["status"].(map[string]interface{})
+	if !ok {
+		return true, nil
+	}
+	conditions, _, _ := unstructured.NestedSlice(status, "conditions")
+	for _, c := range conditions {
+		c, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch c["type"] {
+		case "Ready", "Available", "Succeeded":
+			if c["status"] == "True" {
+				return true, nil
+			}
+			if c["type"] == "Succeeded" && c["status"] == "False" {
+				return false, fmt.Errorf("%s %s failed: %v", us.GetKind(), us.GetName(), c["message"])
+			}
+		}
+	}
+	phase, _, _ := unstructured.NestedString(status, "phase")
+	switch phase {
+	case "Bound", "Active", "Running", "Succeeded":
+		return true, nil
+	case "Failed", "Lost":
+		return false, fmt.Errorf("%s %s is %s", us.GetKind(), us.GetName(), phase)
+	}
+	return false, nil
+}
+
+// createOrUpdate creates data, or replaces the existing resource with it.
+func createOrUpdate(ctx context.Context, client dynamic.ResourceInterface, data *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	created, err := client.Create(ctx, data, metav1.CreateOptions{})
+	if !kerrors.IsAlreadyExists(err) {
+		return created, err
+	}
+	var updated *unstructured.Unstructured
+	// The resource is replaced as a whole, so only changes made to it since it was read
+	// conflict, and the update is retried with the latest version of the resource.
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.Get(ctx, data.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		data.SetResourceVersion(existing.GetResourceVersion())
+		updated, err = client.Update(ctx, data, metav1.UpdateOptions{})
+		return err
+	})
+	return updated, err
+}
+
+// apply applies data with server-side apply as the Triggers field manager.
+func apply(ctx context.Context, client dynamic.ResourceInterface, data *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	body, err := data.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := client.Patch(ctx, data.GetName(), types.ApplyPatchType, body, metav1.PatchOptions{FieldManager: FieldManager})
+	if kerrors.IsConflict(err) {
+		// Fields owned by another manager are not taken over, and retrying does not
+		// resolve the conflict, so the error is not wrapped to not be retried.
+		return nil, fmt.Errorf("couldn't apply resource %s, it conflicts with another field manager: %v", data.GetName(), err)
+	}
+	return applied, err
+}
+
+// patch merges data into the existing resource with a JSON merge patch.
+func patch(ctx context.Context, client dynamic.ResourceInterface, data *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	body, err := data.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	patched, err := client.Patch(ctx, data.GetName(), types.MergePatchType, body, metav1.PatchOptions{FieldManager: FieldManager})
+	if kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("couldn't patch resource %s, it does not exist: %v", data.GetName(), err)
+	}
+	return patched, err
+}
+
 // addLabels adds autogenerated Tekton labels to created resources.
 func addLabels(us *unstructured.Unstructured, labelsToAdd map[string]string) (*unstructured.Unstructured, error) {
 	labels, _, err := unstructured.NestedStringMap(us.Object, "metadata", "labels")
@@ -132,3 +410,23 @@ func addLabels(us *unstructured.Unstructured, labelsToAdd map[string]string) (*u
 	us.SetLabels(labels)
 	return us, nil
 }
+
+// removeAnnotation removes the annotation key of us.
+func removeAnnotation(us *unstructured.Unstructured, key string) {
+	annotations := us.GetAnnotations()
+	delete(annotations, key)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	us.SetAnnotations(annotations)
+}
+
+// addAnnotation sets the annotation key of us to value.
+func addAnnotation(us *unstructured.Unstructured, key, value string) {
+	annotations := us.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	us.SetAnnotations(annotations)
+}
@@ -17,11 +17,17 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/google/go-cmp/cmp"
@@ -29,6 +35,7 @@ import (
 	dynamicclientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
 	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
 	"github.com/tektoncd/triggers/test"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -43,17 +50,70 @@ const (
 	triggerLabel  = triggers.GroupName + triggers.TriggerLabelKey
 	eventIDLabel  = triggers.GroupName + triggers.EventIDLabelKey
 
+	triggerNamespaceLabel = triggers.GroupName + triggers.TriggerNamespaceLabelKey
+	createdByEventLabel   = triggers.GroupName + triggers.CreatedByEventLabelKey
+
 	triggerName = "trigger"
 	eventID     = "12345"
 )
 
 func Test_FindAPIResource_error(t *testing.T) {
-	dc := fakekubeclientset.NewSimpleClientset().Discovery()
-	if _, err := findAPIResource("v1", "Pod", dc); err == nil {
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	test.AddTektonResources(kubeClient)
+	mapper := NewRESTMapper(kubeClient.Discovery())
+	if _, err := findAPIResource("v1", "Pod", mapper); err == nil {
 		t.Error("findAPIResource() did not return error when expected")
 	}
 }
 
+func TestFindAPIResource_NewKind(t *testing.T) {
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	test.AddTektonResources(kubeClient)
+	mapper := NewRESTMapper(kubeClient.Discovery())
+	if _, err := findAPIResource("tekton.dev/v1alpha1", "TaskRun", mapper); err != nil {
+		t.Fatalf("findAPIResource() returned error: %s", err)
+	}
+
+	// Kinds installed after the resources were discovered are found by discovering them again.
+	kubeClient.Resources = append(kubeClient.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.dev/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget"}},
+	})
+	got, err := findAPIResource("example.dev/v1", "Widget", mapper)
+	if err != nil {
+		t.Fatalf("findAPIResource() returned error: %s", err)
+	}
+	want := &metav1.APIResource{Name: "widgets", Namespaced: true, Group: "example.dev", Version: "v1", Kind: "Widget"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("findAPIResource() Diff: -want +got: %s", diff)
+	}
+}
+
+func TestFindAPIResource_RediscoveryInterval(t *testing.T) {
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	test.AddTektonResources(kubeClient)
+	mapper := NewRESTMapper(kubeClient.Discovery())
+	if _, err := findAPIResource("example.dev/v1", "Widget", mapper); err == nil {
+		t.Fatal("findAPIResource() did not return error for a kind that is not installed")
+	}
+
+	// The kind is installed, but not discovered again until the interval has passed.
+	kubeClient.Resources = append(kubeClient.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.dev/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget"}},
+	})
+	if _, err := findAPIResource("example.dev/v1", "Widget", mapper); err == nil {
+		t.Error("findAPIResource() discovered resources again within the rediscovery interval")
+	}
+
+	m := mapper.(*rediscoveringRESTMapper)
+	gvk := schema.GroupVersionKind{Group: "example.dev", Version: "v1", Kind: "Widget"}
+	m.rediscovered[gvk] = m.rediscovered[gvk].Add(-rediscoveryInterval)
+	if _, err := findAPIResource("example.dev/v1", "Widget", mapper); err != nil {
+		t.Errorf("findAPIResource() returned error after the rediscovery interval: %s", err)
+	}
+}
+
 func TestFindAPIResource(t *testing.T) {
 	// Create fake kubeclient with list of resources
 	kubeClient := fakekubeclientset.NewSimpleClientset()
@@ -70,7 +130,7 @@ func TestFindAPIResource(t *testing.T) {
 		}},
 	}}
 	test.AddTektonResources(kubeClient)
-	dc := kubeClient.Discovery()
+	mapper := NewRESTMapper(kubeClient.Discovery())
 
 	tests := []struct {
 		apiVersion string
@@ -118,7 +178,7 @@ func TestFindAPIResource(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("%s_%s", tt.apiVersion, tt.kind), func(t *testing.T) {
-			got, err := findAPIResource(tt.apiVersion, tt.kind, dc)
+			got, err := findAPIResource(tt.apiVersion, tt.kind, mapper)
 			if err != nil {
 				t.Errorf("findAPIResource() returned error: %s", err)
 			} else if diff := cmp.Diff(tt.want, got); diff != "" {
@@ -130,7 +190,7 @@ func TestFindAPIResource(t *testing.T) {
 
 func TestCreateResource(t *testing.T) {
 	elName := "foo-el"
-	elNamespace := "bar"
+	triggerNamespace := "bar"
 
 	kubeClient := fakekubeclientset.NewSimpleClientset()
 	test.AddTektonResources(kubeClient)
@@ -140,10 +200,21 @@ func TestCreateResource(t *testing.T) {
 
 	logger := zaptest.NewLogger(t)
 
+	sampledTrace := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
 	tests := []struct {
 		name string
 		json []byte
-		want resourcev1.PipelineResource
+		// trace is the context of the trace the resource is created in, if any.
+		trace *trace.SpanContext
+		// record is whether spans are recorded, as they are when traces are exported.
+		record bool
+		want   resourcev1.PipelineResource
 	}{{
 		name: "PipelineResource without namespace",
 		json: json.RawMessage(`{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","creationTimestamp":null,"labels":{"woriginal-label-1":"label-1"}},"spec":{"type":"","params":[{"name":"foo","value":"bar\r\nbaz"}]},"status":{}}`),
@@ -155,10 +226,12 @@ func TestCreateResource(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "my-pipelineresource",
 				Labels: map[string]string{
-					"woriginal-label-1": "label-1",
-					resourceLabel:       elName,
-					triggerLabel:        triggerName,
-					eventIDLabel:        eventID,
+					"woriginal-label-1":   "label-1",
+					resourceLabel:         elName,
+					triggerLabel:          triggerName,
+					eventIDLabel:          eventID,
+					triggerNamespaceLabel: triggerNamespace,
+					createdByEventLabel:   "true",
 				},
 			},
 			Spec: resourcev1.PipelineResourceSpec{
@@ -181,10 +254,62 @@ func TestCreateResource(t *testing.T) {
 				Namespace: "foo",
 				Name:      "my-pipelineresource",
 				Labels: map[string]string{
-					"woriginal-label-1": "label-1",
-					resourceLabel:       elName,
-					triggerLabel:        triggerName,
-					eventIDLabel:        eventID,
+					"woriginal-label-1":   "label-1",
+					resourceLabel:         elName,
+					triggerLabel:          triggerName,
+					eventIDLabel:          eventID,
+					triggerNamespaceLabel: triggerNamespace,
+					createdByEventLabel:   "true",
+				},
+			},
+			Spec:   resourcev1.PipelineResourceSpec{},
+			Status: &resourcev1.PipelineResourceStatus{},
+		},
+	}, {
+		name:   "PipelineResource created in a sampled trace",
+		json:   json.RawMessage(`{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","namespace":"traced","creationTimestamp":null},"spec":{"type":"","params":null},"status":{}}`),
+		trace:  &sampledTrace,
+		record: true,
+		want: resourcev1.PipelineResource{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       "PipelineResource",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "traced",
+				Name:      "my-pipelineresource",
+				Labels: map[string]string{
+					resourceLabel:         elName,
+					triggerLabel:          triggerName,
+					eventIDLabel:          eventID,
+					triggerNamespaceLabel: triggerNamespace,
+					createdByEventLabel:   "true",
+				},
+				Annotations: map[string]string{
+					triggers.TraceIDAnnotation: "4bf92f3577b34da6a3ce929d0e0e4736",
+				},
+			},
+			Spec:   resourcev1.PipelineResourceSpec{},
+			Status: &resourcev1.PipelineResourceStatus{},
+		},
+	}, {
+		name:  "PipelineResource created in a sampled trace that is not recorded",
+		json:  json.RawMessage(`{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","namespace":"untraced","creationTimestamp":null},"spec":{"type":"","params":null},"status":{}}`),
+		trace: &sampledTrace,
+		want: resourcev1.PipelineResource{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       "PipelineResource",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "untraced",
+				Name:      "my-pipelineresource",
+				Labels: map[string]string{
+					resourceLabel:         elName,
+					triggerLabel:          triggerName,
+					eventIDLabel:          eventID,
+					triggerNamespaceLabel: triggerNamespace,
+					createdByEventLabel:   "true",
 				},
 			},
 			Spec:   resourcev1.PipelineResourceSpec{},
@@ -194,7 +319,15 @@ func TestCreateResource(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dynamicClient.ClearActions()
-			if err := Create(logger.Sugar(), tt.json, triggerName, eventID, elName, elNamespace, kubeClient.Discovery(), dynamicSet); err != nil {
+			ctx := context.Background()
+			if tt.trace != nil {
+				ctx = trace.ContextWithRemoteSpanContext(ctx, *tt.trace)
+			}
+			if tt.record {
+				otel.SetTracerProvider(sdktrace.NewTracerProvider())
+				defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+			}
+			if _, err := Create(ctx, logger.Sugar(), tt.json, triggerName, eventID, elName, triggerNamespace, NewRESTMapper(kubeClient.Discovery()), dynamicSet); err != nil {
 				t.Errorf("createResource() returned error: %s", err)
 			}
 
@@ -205,7 +338,7 @@ func TestCreateResource(t *testing.T) {
 			}
 			namespace := tt.want.Namespace
 			if namespace == "" {
-				namespace = elNamespace
+				namespace = triggerNamespace
 			}
 			want := []ktesting.Action{ktesting.NewCreateAction(gvr, namespace, test.ToUnstructured(t, tt.want))}
 			if diff := cmp.Diff(want, dynamicClient.Actions()); diff != "" {
@@ -324,3 +457,217 @@ func Test_AddLabels(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateResource_CreatePolicy(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "pipelineresources"}
+	template := func(policy, name, value string) json.RawMessage {
+		return json.RawMessage(fmt.Sprintf(`{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":%q,"namespace":"bar","annotations":{%q:%q}},"spec":{"type":"git","params":[{"name":"url","value":%q}]}}`,
+			name, triggers.CreatePolicyAnnotation, policy, value))
+	}
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/v1alpha1",
+		"kind":       "PipelineResource",
+		"metadata": map[string]interface{}{
+			"name":            "my-pipelineresource",
+			"namespace":       "bar",
+			"resourceVersion": "1",
+			"labels":          map[string]interface{}{"app": "preview"},
+		},
+		"spec": map[string]interface{}{
+			"type":   "git",
+			"params": []interface{}{map[string]interface{}{"name": "url", "value": "old"}},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		template json.RawMessage
+		existing bool
+		// reactor handles the patches of server-side apply, which the fake client does not support.
+		reactor    ktesting.ReactionFunc
+		wantLabels map[string]string
+		wantErr    bool
+	}{{
+		name:       "create or update creates a missing resource",
+		template:   template("CreateOrUpdate", "my-pipelineresource", "new"),
+		wantLabels: map[string]string{resourceLabel: "foo-el", triggerLabel: triggerName, eventIDLabel: eventID, triggerNamespaceLabel: "bar"},
+	}, {
+		name:       "create or update replaces an existing resource",
+		template:   template("CreateOrUpdate", "my-pipelineresource", "new"),
+		existing:   true,
+		wantLabels: map[string]string{resourceLabel: "foo-el", triggerLabel: triggerName, eventIDLabel: eventID, triggerNamespaceLabel: "bar"},
+	}, {
+		name:       "patch merges into an existing resource",
+		template:   template("Patch", "my-pipelineresource", "new"),
+		existing:   true,
+		wantLabels: map[string]string{"app": "preview", resourceLabel: "foo-el", triggerLabel: triggerName, eventIDLabel: eventID, triggerNamespaceLabel: "bar"},
+	}, {
+		name:     "patch fails for a missing resource",
+		template: template("Patch", "my-pipelineresource", "new"),
+		wantErr:  true,
+	}, {
+		name:     "apply sends an apply patch",
+		template: template("Apply", "my-pipelineresource", "new"),
+		reactor: func(action ktesting.Action) (bool, runtime.Object, error) {
+			patch := action.(ktesting.PatchAction)
+			if patch.GetPatchType() != "application/apply-patch+yaml" {
+				return true, nil, fmt.Errorf("unexpected patch type %s", patch.GetPatchType())
+			}
+			obj := &unstructured.Unstructured{}
+			return true, obj, obj.UnmarshalJSON(patch.GetPatch())
+		},
+		wantLabels: map[string]string{resourceLabel: "foo-el", triggerLabel: triggerName, eventIDLabel: eventID, triggerNamespaceLabel: "bar"},
+	}, {
+		name:     "apply fails on conflicts",
+		template: template("Apply", "my-pipelineresource", "new"),
+		reactor: func(action ktesting.Action) (bool, runtime.Object, error) {
+			return true, nil, kerrors.NewConflict(gvr.GroupResource(), "my-pipelineresource", errors.New(`conflict with "kubectl"`))
+		},
+		wantErr: true,
+	}, {
+		name:     "unnamed resource with a policy",
+		template: template("CreateOrUpdate", "", "new"),
+		wantErr:  true,
+	}, {
+		name:     "unknown policy",
+		template: template("Replace", "my-pipelineresource", "new"),
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fakekubeclientset.NewSimpleClientset()
+			test.AddTektonResources(kubeClient)
+			var objects []runtime.Object
+			if tt.existing {
+				objects = append(objects, existing.DeepCopy())
+			}
+			dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			if tt.reactor != nil {
+				dynamicClient.PrependReactor("patch", "pipelineresources", tt.reactor)
+			}
+
+			got, err := Create(context.Background(), zaptest.NewLogger(t).Sugar(), tt.template, triggerName, eventID, "foo-el", "bar", NewRESTMapper(kubeClient.Discovery()), dynamicClient)
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("Create() unexpected error: %v", err)
+				}
+				if kerrors.IsConflict(err) {
+					t.Errorf("Create() error %v is retried as a conflict", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatal("Create() expected error")
+			}
+			if diff := cmp.Diff(tt.wantLabels, got.GetLabels()); diff != "" {
+				t.Errorf("unexpected labels -want,+got: %s", diff)
+			}
+			if _, ok := got.GetAnnotations()[triggers.CreatePolicyAnnotation]; ok {
+				t.Errorf("create policy annotation was not removed: %v", got.GetAnnotations())
+			}
+			params, _, _ := unstructured.NestedSlice(got.Object, "spec", "params")
+			if diff := cmp.Diff([]interface{}{map[string]interface{}{"name": "url", "value": "new"}}, params); diff != "" {
+				t.Errorf("unexpected params -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateResource_WaitForReady(t *testing.T) {
+	defer func(timeout time.Duration) { readyTimeout = timeout }(readyTimeout)
+	readyTimeout = 3 * time.Second
+
+	template := func(status string) json.RawMessage {
+		return json.RawMessage(fmt.Sprintf(`{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","namespace":"bar","annotations":{%q:"true",%q:"git"}},"spec":{"type":"git"}%s}`,
+			triggers.WaitForReadyAnnotation, triggers.ResourceNameAnnotation, status))
+	}
+	readyStatus := map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+	}
+
+	tests := []struct {
+		name     string
+		template json.RawMessage
+		// latestStatus is the status the resource is read with after it is created.
+		latestStatus map[string]interface{}
+		wantErr      bool
+	}{{
+		name:     "resource without status",
+		template: template(""),
+	}, {
+		name:         "resource that becomes ready",
+		template:     template(`,"status":{"conditions":[{"type":"Ready","status":"Unknown"}]}`),
+		latestStatus: readyStatus,
+	}, {
+		name:     "resource with a bound phase",
+		template: template(`,"status":{"phase":"Bound"}`),
+	}, {
+		name:     "resource that failed",
+		template: template(`,"status":{"conditions":[{"type":"Succeeded","status":"False","message":"boom"}]}`),
+		wantErr:  true,
+	}, {
+		name:         "resource that is not ready in time",
+		template:     template(`,"status":{"phase":"Pending"}`),
+		latestStatus: map[string]interface{}{"phase": "Pending"},
+		wantErr:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fakekubeclientset.NewSimpleClientset()
+			test.AddTektonResources(kubeClient)
+			dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+			dynamicClient.PrependReactor("get", "pipelineresources", func(action ktesting.Action) (bool, runtime.Object, error) {
+				obj := &unstructured.Unstructured{Object: map[string]interface{}{
+					"apiVersion": "tekton.dev/v1alpha1",
+					"kind":       "PipelineResource",
+					"metadata":   map[string]interface{}{"name": "my-pipelineresource", "namespace": "bar"},
+					"status":     tt.latestStatus,
+				}}
+				return true, obj, nil
+			})
+
+			got, err := Create(context.Background(), zaptest.NewLogger(t).Sugar(), tt.template, triggerName, eventID, "foo-el", "bar", NewRESTMapper(kubeClient.Discovery()), dynamicClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.GetAnnotations()) != 0 {
+				t.Errorf("order annotations were not removed: %v", got.GetAnnotations())
+			}
+			if tt.latestStatus != nil {
+				if diff := cmp.Diff(tt.latestStatus, got.Object["status"]); diff != "" {
+					t.Errorf("Create() did not return the ready resource -want,+got: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	pr := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/v1alpha1",
+		"kind":       "PipelineResource",
+		"metadata": map[string]interface{}{
+			"name":      "my-pipelineresource",
+			"namespace": "bar",
+		},
+	}}
+	gvr := schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "pipelineresources"}
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	test.AddTektonResources(kubeClient)
+	mapper := NewRESTMapper(kubeClient.Discovery())
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), pr.DeepCopy())
+
+	if err := Delete(context.Background(), pr, mapper, dynamicClient); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if _, err := dynamicClient.Resource(gvr).Namespace("bar").Get(context.Background(), pr.GetName(), metav1.GetOptions{}); !kerrors.IsNotFound(err) {
+		t.Errorf("resource was not deleted: %v", err)
+	}
+	// Resources that were already deleted are ignored.
+	if err := Delete(context.Background(), pr, mapper, dynamicClient); err != nil {
+		t.Errorf("Delete() of a deleted resource unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/http/pprof"
+	"net/url"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// AdminOptions configures the endpoints served on the admin port of the EventListener.
+type AdminOptions struct {
+	// Synced reports whether the informers the EventListener reads from have synced.
+	Synced []cache.InformerSynced
+	// EnablePprof serves the pprof endpoints under /debug/pprof/.
+	EnablePprof bool
+	// MetricsURL is the URL of the metrics endpoint of the EventListener. If set, it is
+	// served under /metrics.
+	MetricsURL *url.URL
+}
+
+// NewAdminHandler returns the handler of the admin port of the EventListener. The admin
+// port serves the liveness and readiness endpoints, and optionally pprof and metrics,
+// so that they are not exposed along with the events endpoint.
+func (r Sink) NewAdminHandler(opts AdminOptions) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/live", Live)
+	mux.Handle("/ready", r.ReadyHandler(opts.Synced...))
+	if opts.EnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	if opts.MetricsURL != nil {
+		mux.Handle("/metrics", httputil.NewSingleHostReverseProxy(opts.MetricsURL))
+	}
+	return mux
+}
+
+// Live responds to liveness probes. The EventListener is live as long as it serves requests.
+func Live(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "ok")
+}
+
+// ReadyHandler responds to readiness probes. The EventListener is ready once the given
+// informers have synced and the EventListener it serves is found.
+func (r Sink) ReadyHandler(synced ...cache.InformerSynced) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		for _, s := range synced {
+			if !s() {
+				http.Error(w, "informers have not synced", http.StatusServiceUnavailable)
+				return
+			}
+		}
+		if _, err := r.EventListenerLister.EventListeners(r.EventListenerNamespace).Get(r.EventListenerName); err != nil {
+			http.Error(w, fmt.Sprintf("failed to get EventListener %s: %v", r.EventListenerName, err), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "ok")
+	})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNewAdminHandler(t *testing.T) {
+	metrics := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "eventlistener_event_count 1")
+	}))
+	defer metrics.Close()
+	metricsURL, err := url.Parse(metrics.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	synced := func() bool { return true }
+	notSynced := func() bool { return false }
+	el := &triggersv1beta1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-el",
+			Namespace: namespace,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		resources  test.Resources
+		opts       AdminOptions
+		path       string
+		wantStatus int
+		wantBody   string
+	}{{
+		name:       "live",
+		path:       "/live",
+		wantStatus: http.StatusOK,
+		wantBody:   "ok",
+	}, {
+		name:       "ready",
+		resources:  test.Resources{EventListeners: []*triggersv1beta1.EventListener{el}},
+		opts:       AdminOptions{Synced: []cache.InformerSynced{synced}},
+		path:       "/ready",
+		wantStatus: http.StatusOK,
+		wantBody:   "ok",
+	}, {
+		name:       "not ready until informers sync",
+		resources:  test.Resources{EventListeners: []*triggersv1beta1.EventListener{el}},
+		opts:       AdminOptions{Synced: []cache.InformerSynced{synced, notSynced}},
+		path:       "/ready",
+		wantStatus: http.StatusServiceUnavailable,
+	}, {
+		name:       "not ready without EventListener",
+		opts:       AdminOptions{Synced: []cache.InformerSynced{synced}},
+		path:       "/ready",
+		wantStatus: http.StatusServiceUnavailable,
+	}, {
+		name:       "pprof disabled",
+		path:       "/debug/pprof/",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "pprof enabled",
+		opts:       AdminOptions{EnablePprof: true},
+		path:       "/debug/pprof/",
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "metrics not configured",
+		path:       "/metrics",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "metrics",
+		opts:       AdminOptions{MetricsURL: metricsURL},
+		path:       "/metrics",
+		wantStatus: http.StatusOK,
+		wantBody:   "eventlistener_event_count 1",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, _ := getSinkAssets(t, tc.resources, "my-el", nil)
+			ts := httptest.NewServer(sink.NewAdminHandler(tc.opts))
+			defer ts.Close()
+
+			resp, err := http.Get(ts.URL + tc.path)
+			if err != nil {
+				t.Fatalf("error sending request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("Status code mismatch: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.wantBody == "" {
+				return
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response: %s", err)
+			}
+			if string(body) != tc.wantBody {
+				t.Errorf("body = %q, want %q", body, tc.wantBody)
+			}
+		})
+	}
+}
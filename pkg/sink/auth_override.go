@@ -18,42 +18,69 @@ package sink
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	dynamicClientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
 	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
 	"go.uber.org/zap"
-	discoveryclient "k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
-// AuthOverride is an interface that constructs a discovery client for the ServerResourceInterface
-// and a dynamic client for the Tekton Resources, using the token provide as the bearer token in the
-// REST config used to build those client.  The other non-credential related parameters for the
-// REST client used are copied from the in cluster config of the event sink.
+// AuthOverride is an interface that constructs a dynamic client for the Tekton Resources that
+// impersonates a service account. The other non-credential related parameters for the REST
+// client used are copied from the in cluster config of the event sink.
 type AuthOverride interface {
 	OverrideAuthentication(sa string,
 		namespace string,
 		log *zap.SugaredLogger,
-		defaultDiscoveryClient discoveryclient.ServerResourcesInterface,
-		defaultDynamicClient dynamic.Interface) (discoveryClient discoveryclient.ServerResourcesInterface,
-		dynamicClient dynamic.Interface,
+		defaultDynamicClient dynamic.Interface) (dynamicClient dynamic.Interface,
 		err error)
+	// Invalidate discards the client of the service account sa in namespace, if it is
+	// cached, so that the next call to OverrideAuthentication creates a new one.
+	Invalidate(sa string, namespace string)
 }
 
+// DefaultAuthOverride impersonates service accounts with the in cluster config of the
+// event sink. The zero value creates new clients on every call; use NewDefaultAuthOverride
+// to reuse them.
 type DefaultAuthOverride struct {
+	cache *clientCache
+}
+
+// NewDefaultAuthOverride returns a DefaultAuthOverride that reuses the client it creates
+// for a service account for ttl. It records the hits and misses of its cache with
+// recorder, if it is not nil.
+func NewDefaultAuthOverride(ttl time.Duration, recorder *Recorder) DefaultAuthOverride {
+	return DefaultAuthOverride{cache: newClientCache(ttl, recorder)}
 }
 
 func (r DefaultAuthOverride) OverrideAuthentication(sa string,
 	namespace string,
 	log *zap.SugaredLogger,
-	defaultDiscoverClient discoveryclient.ServerResourcesInterface,
-	defaultDynamicClient dynamic.Interface) (discoveryClient discoveryclient.ServerResourcesInterface,
-	dynamicClient dynamic.Interface,
+	defaultDynamicClient dynamic.Interface) (dynamicClient dynamic.Interface,
+	err error) {
+	if r.cache == nil {
+		return newImpersonatingClient(sa, namespace, log, defaultDynamicClient)
+	}
+	return r.cache.get(sa, namespace, func() (dynamic.Interface, error) {
+		return newImpersonatingClient(sa, namespace, log, defaultDynamicClient)
+	})
+}
+
+func (r DefaultAuthOverride) Invalidate(sa string, namespace string) {
+	if r.cache != nil {
+		r.cache.invalidate(sa, namespace)
+	}
+}
+
+func newImpersonatingClient(sa string,
+	namespace string,
+	log *zap.SugaredLogger,
+	defaultDynamicClient dynamic.Interface) (dynamicClient dynamic.Interface,
 	err error) {
 	dynamicClient = defaultDynamicClient
-	discoveryClient = defaultDiscoverClient
 	clusterConfig, err := rest.InClusterConfig()
 	if err != nil {
 		log.Errorf("overrideAuthentication: problem getting in cluster config: %#v\n", err)
@@ -67,13 +94,67 @@ func (r DefaultAuthOverride) OverrideAuthentication(sa string,
 		log.Errorf("overrideAuthentication: problem getting dynamic client set: %#v\n", err)
 		return
 	}
-	kubeClient, err := kubernetes.NewForConfig(clusterConfig)
-	if err != nil {
-		log.Errorf("overrideAuthentication: problem getting kube client: %#v\n", err)
-		return
-	}
 	dynamicClient = dynamicClientset.New(tekton.WithClient(dc))
-	discoveryClient = kubeClient.Discovery()
 
 	return
 }
+
+// clientCache caches the clients of service accounts, keyed by their namespace and name.
+type clientCache struct {
+	ttl      time.Duration
+	recorder *Recorder
+	// now returns the current time; it is replaced in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]clientCacheEntry
+}
+
+type clientCacheEntry struct {
+	client  dynamic.Interface
+	expires time.Time
+}
+
+func newClientCache(ttl time.Duration, recorder *Recorder) *clientCache {
+	return &clientCache{
+		ttl:      ttl,
+		recorder: recorder,
+		now:      time.Now,
+		entries:  map[string]clientCacheEntry{},
+	}
+}
+
+// get returns the cached client of the service account sa in namespace, or the client
+// returned by create if none is cached or the cached client expired. Clients that fail
+// to be created are not cached.
+func (c *clientCache) get(sa, namespace string, create func() (dynamic.Interface, error)) (dynamic.Interface, error) {
+	key := namespace + "/" + sa
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if e, ok := c.entries[key]; ok && now.Before(e.expires) {
+		c.recordClientCacheLookup(clientCacheHitTag, len(c.entries))
+		return e.client, nil
+	}
+	// Service accounts that are no longer used are dropped when a client is created,
+	// so that the cache does not grow with every service account it has ever seen.
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	client, err := create()
+	if err != nil {
+		c.recordClientCacheLookup(clientCacheMissTag, len(c.entries))
+		return client, err
+	}
+	c.entries[key] = clientCacheEntry{client: client, expires: now.Add(c.ttl)}
+	c.recordClientCacheLookup(clientCacheMissTag, len(c.entries))
+	return client, nil
+}
+
+func (c *clientCache) invalidate(sa, namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, namespace+"/"+sa)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestClientCache(t *testing.T) {
+	now := time.Now()
+	c := newClientCache(time.Minute, nil)
+	c.now = func() time.Time { return now }
+
+	created := 0
+	create := func() (dynamic.Interface, error) {
+		created++
+		return fakedynamic.NewSimpleDynamicClient(runtime.NewScheme()), nil
+	}
+	get := func(sa, namespace string) dynamic.Interface {
+		t.Helper()
+		client, err := c.get(sa, namespace, create)
+		if err != nil {
+			t.Fatalf("get() unexpected error: %v", err)
+		}
+		return client
+	}
+
+	first := get("sa", "ns")
+	if got := get("sa", "ns"); got != first || created != 1 {
+		t.Errorf("expected the cached client to be reused, created %d clients", created)
+	}
+	if get("sa", "other-ns"); created != 2 {
+		t.Errorf("expected a client to be created for another namespace, created %d clients", created)
+	}
+
+	c.invalidate("sa", "ns")
+	if got := get("sa", "ns"); got == first || created != 3 {
+		t.Errorf("expected a new client after invalidation, created %d clients", created)
+	}
+
+	now = now.Add(time.Minute)
+	if get("sa", "ns"); created != 4 {
+		t.Errorf("expected a new client after the ttl, created %d clients", created)
+	}
+	if _, ok := c.entries["other-ns/sa"]; ok {
+		t.Error("expected the expired client of other-ns/sa to be dropped")
+	}
+}
+
+func TestClientCache_Error(t *testing.T) {
+	c := newClientCache(time.Minute, nil)
+	calls := 0
+	create := func() (dynamic.Interface, error) {
+		calls++
+		return nil, errors.New("no in cluster config")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.get("sa", "ns", create); err == nil {
+			t.Fatal("get() expected error")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected clients that failed to be created not to be cached, create called %d times", calls)
+	}
+}
+
+// fakeAuthOverride returns the default dynamic client and records the service
+// accounts whose clients are invalidated.
+type fakeAuthOverride struct {
+	invalidated []string
+}
+
+func (f *fakeAuthOverride) OverrideAuthentication(sa string, namespace string, log *zap.SugaredLogger, defaultDynamicClient dynamic.Interface) (dynamic.Interface, error) {
+	return defaultDynamicClient, nil
+}
+
+func (f *fakeAuthOverride) Invalidate(sa string, namespace string) {
+	f.invalidated = append(f.invalidated, namespace+"/"+sa)
+}
+
+func TestCreateResources_InvalidatesClient(t *testing.T) {
+	tr := test.RawExtension(t, pipelinev1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "TaskRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-taskrun",
+			Namespace: namespace,
+		},
+	})
+	tests := []struct {
+		name            string
+		err             error
+		wantInvalidated int
+	}{{
+		name:            "unauthorized",
+		err:             kerrors.NewUnauthorized("token expired"),
+		wantInvalidated: 1,
+	}, {
+		name: "forbidden",
+		err:  kerrors.NewForbidden(pipelinev1.Resource("taskruns"), "my-taskrun", errors.New("not allowed")),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := getSinkAssets(t, test.Resources{}, "my-el", nil)
+			auth := &fakeAuthOverride{}
+			s.Auth = auth
+			dynamicClient.PrependReactor("create", "taskruns", func(ktesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.err
+			})
+
+			if _, err := s.CreateResources(context.Background(), namespace, "my-sa", []json.RawMessage{tr.Raw}, "my-trigger", eventID, s.Logger, nil); err == nil {
+				t.Fatal("CreateResources() expected error")
+			}
+			if len(auth.invalidated) != tc.wantInvalidated {
+				t.Errorf("invalidated %v, want %d invalidations", auth.invalidated, tc.wantInvalidated)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CertificateExpiryWarningPeriod is how long before the serving certificate expires
+// the EventListener starts warning that it is about to expire.
+const CertificateExpiryWarningPeriod = 7 * 24 * time.Hour
+
+// CertificateReloader serves the TLS certificate of an EventListener from files, and
+// reloads it when the files change, such as when the Secret they are mounted from is
+// rotated. Connections that are already established keep the certificate they were
+// established with.
+type CertificateReloader struct {
+	certFile, keyFile string
+	logger            *zap.SugaredLogger
+	recorder          *Recorder
+
+	mu sync.RWMutex
+	// certPEM and keyPEM are the contents of the files the certificate was loaded from.
+	certPEM, keyPEM []byte
+	cert            *tls.Certificate
+	notAfter        time.Time
+}
+
+// NewCertificateReloader returns a CertificateReloader that serves the certificate and
+// key in certFile and keyFile. It records reloads and the expiry of the certificate
+// with recorder, if it is not nil.
+func NewCertificateReloader(certFile, keyFile string, logger *zap.SugaredLogger, recorder *Recorder) (*CertificateReloader, error) {
+	c := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+		recorder: recorder,
+	}
+	if _, err := c.reload(); err != nil {
+		return nil, err
+	}
+	c.recordCertificateExpiry(c.notAfter, time.Now())
+	return c, nil
+}
+
+// GetCertificate returns the current certificate. It is the GetCertificate callback of
+// the tls.Config of the EventListener.
+func (c *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+// Start checks the certificate files for changes every interval until ctx is done.
+func (c *CertificateReloader) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.check(now)
+		}
+	}
+}
+
+// check reloads the certificate if its files changed, and reports its expiry.
+func (c *CertificateReloader) check(now time.Time) {
+	reloaded, err := c.reload()
+	switch {
+	case err != nil:
+		// The files of a Secret are updated together, so the previous certificate keeps
+		// being served until both files hold a valid key pair.
+		c.logger.Errorf("Failed to reload TLS certificate, serving the previous certificate: %v", err)
+		c.recordCertificateReload(failTag)
+	case reloaded:
+		c.logger.Infof("Reloaded TLS certificate from %s, it expires at %s", c.certFile, c.notAfter.Format(time.RFC3339))
+		c.recordCertificateReload(successTag)
+	}
+
+	c.mu.RLock()
+	notAfter := c.notAfter
+	c.mu.RUnlock()
+	if remaining := notAfter.Sub(now); remaining < CertificateExpiryWarningPeriod {
+		c.logger.Warnf("TLS certificate %s expires in %s, at %s", c.certFile, remaining.Round(time.Second), notAfter.Format(time.RFC3339))
+	}
+	c.recordCertificateExpiry(notAfter, now)
+}
+
+// reload loads the certificate from its files if they changed since it was last loaded,
+// and returns true if it did.
+func (c *CertificateReloader) reload() (bool, error) {
+	certPEM, err := ioutil.ReadFile(c.certFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TLS certificate: %w", err)
+	}
+	keyPEM, err := ioutil.ReadFile(c.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TLS key: %w", err)
+	}
+
+	c.mu.RLock()
+	unchanged := bytes.Equal(certPEM, c.certPEM) && bytes.Equal(keyPEM, c.keyPEM)
+	c.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return false, fmt.Errorf("failed to parse TLS certificate: %w", err)
+	}
+	cert.Leaf = leaf
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.certPEM, c.keyPEM = certPEM, keyPEM
+	c.cert = &cert
+	c.notAfter = leaf.NotAfter
+	return true, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+)
+
+// servingCertPEM returns a PEM encoded self-signed serving certificate for commonName
+// that expires at notAfter, and its PEM encoded key.
+func servingCertPEM(t *testing.T, commonName string, notAfter time.Time) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func servedCommonName(t *testing.T, c *CertificateReloader) string {
+	t.Helper()
+	cert, err := c.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate() unexpected error: %v", err)
+	}
+	return cert.Leaf.Subject.CommonName
+}
+
+func TestCertificateReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	write := func(certPEM, keyPEM []byte) {
+		t.Helper()
+		if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Certificates store their expiry with a precision of a second.
+	now := time.Now().Truncate(time.Second)
+	write(servingCertPEM(t, "original", now.Add(30*24*time.Hour)))
+
+	c, err := NewCertificateReloader(certFile, keyFile, zaptest.NewLogger(t).Sugar(), nil)
+	if err != nil {
+		t.Fatalf("NewCertificateReloader() unexpected error: %v", err)
+	}
+	if got := servedCommonName(t, c); got != "original" {
+		t.Errorf("served certificate %q, want original", got)
+	}
+
+	if reloaded, err := c.reload(); err != nil || reloaded {
+		t.Errorf("reload() of unchanged files = %t, %v, want false, nil", reloaded, err)
+	}
+
+	rotatedNotAfter := now.Add(60 * 24 * time.Hour)
+	write(servingCertPEM(t, "rotated", rotatedNotAfter))
+	c.check(now)
+	if got := servedCommonName(t, c); got != "rotated" {
+		t.Errorf("served certificate %q, want rotated", got)
+	}
+	if !c.notAfter.Equal(rotatedNotAfter) {
+		t.Errorf("certificate expires at %s, want %s", c.notAfter, rotatedNotAfter)
+	}
+
+	// A key that does not match the certificate is not loaded, and the previous
+	// certificate keeps being served.
+	certPEM, _ := servingCertPEM(t, "mismatched", now.Add(time.Hour))
+	_, keyPEM := servingCertPEM(t, "other", now.Add(time.Hour))
+	write(certPEM, keyPEM)
+	c.check(now)
+	if got := servedCommonName(t, c); got != "rotated" {
+		t.Errorf("served certificate %q after a failed reload, want rotated", got)
+	}
+}
+
+func TestNewCertificateReloader_Error(t *testing.T) {
+	certPEM, keyPEM := servingCertPEM(t, "el", time.Now().Add(time.Hour))
+	certFile := writeFile(t, "tls.crt", certPEM)
+	keyFile := writeFile(t, "tls.key", keyPEM)
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+	}{{
+		name:     "missing certificate",
+		certFile: filepath.Join(t.TempDir(), "missing.crt"),
+		keyFile:  keyFile,
+	}, {
+		name:     "missing key",
+		certFile: certFile,
+		keyFile:  filepath.Join(t.TempDir(), "missing.key"),
+	}, {
+		name:     "invalid key pair",
+		certFile: certFile,
+		keyFile:  writeFile(t, "invalid.key", []byte("not a key")),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewCertificateReloader(tc.certFile, tc.keyFile, zaptest.NewLogger(t).Sugar(), nil); err == nil {
+				t.Error("NewCertificateReloader() expected error")
+			}
+		})
+	}
+}
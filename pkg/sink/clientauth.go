@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+)
+
+// ClientAuthTLSConfig returns the TLS configuration of an EventListener that verifies the
+// certificates of its clients with the PEM encoded CA certificates in caFile. mode is
+// whether clients must present a certificate, Required or Optional.
+func ClientAuthTLSConfig(caFile, mode string) (*tls.Config, error) {
+	var clientAuth tls.ClientAuthType
+	switch triggersv1.ClientAuthMode(mode) {
+	case "", triggersv1.ClientAuthRequired:
+		clientAuth = tls.RequireAndVerifyClientCert
+	case triggersv1.ClientAuthOptional:
+		clientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return nil, fmt.Errorf("invalid client auth mode %q", mode)
+	}
+	caBundle, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no certificates found in client CA bundle %s", caFile)
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: clientAuth,
+	}, nil
+}
+
+// clientSubject returns the subject of the verified certificate that the client of
+// request authenticated with, or "" if the client did not send a certificate.
+func clientSubject(request *http.Request) string {
+	if request.TLS == nil || len(request.TLS.VerifiedChains) == 0 || len(request.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return request.TLS.VerifiedChains[0][0].Subject.String()
+}
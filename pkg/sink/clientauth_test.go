@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// testCA is a certificate authority that issues client certificates in tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a client certificate for subject signed by the CA.
+func (ca *testCA) issue(t *testing.T, subject pkix.Name) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// writeFile writes data to a file in a temporary directory and returns its path.
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestClientAuthTLSConfig(t *testing.T) {
+	ca := newTestCA(t)
+	caFile := writeFile(t, "ca.crt", ca.pem)
+	clientCert := ca.issue(t, pkix.Name{CommonName: "producer", Organization: []string{"Example"}})
+	otherCert := newTestCA(t).issue(t, pkix.Name{CommonName: "intruder"})
+
+	tests := []struct {
+		name        string
+		mode        string
+		clientCerts []tls.Certificate
+		wantSubject string
+		wantErr     bool
+	}{{
+		name:        "required with a certificate",
+		mode:        "Required",
+		clientCerts: []tls.Certificate{clientCert},
+		wantSubject: "CN=producer,O=Example",
+	}, {
+		name:    "required without a certificate",
+		mode:    "Required",
+		wantErr: true,
+	}, {
+		name:        "required with a certificate of another CA",
+		mode:        "Required",
+		clientCerts: []tls.Certificate{otherCert},
+		wantErr:     true,
+	}, {
+		name:        "optional with a certificate",
+		mode:        "Optional",
+		clientCerts: []tls.Certificate{clientCert},
+		wantSubject: "CN=producer,O=Example",
+	}, {
+		name: "optional without a certificate",
+		mode: "Optional",
+	}, {
+		name:        "optional with a certificate of another CA",
+		mode:        "Optional",
+		clientCerts: []tls.Certificate{otherCert},
+		wantErr:     true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig, err := ClientAuthTLSConfig(caFile, tc.mode)
+			if err != nil {
+				t.Fatalf("ClientAuthTLSConfig() unexpected error: %v", err)
+			}
+			var gotSubject string
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotSubject = clientSubject(r)
+			}))
+			srv.TLS = tlsConfig
+			srv.StartTLS()
+			defer srv.Close()
+
+			client := srv.Client()
+			client.Transport.(*http.Transport).TLSClientConfig.Certificates = tc.clientCerts
+			resp, err := client.Get(srv.URL)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("request error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			if gotSubject != tc.wantSubject {
+				t.Errorf("client subject = %q, want %q", gotSubject, tc.wantSubject)
+			}
+		})
+	}
+}
+
+func TestClientAuthTLSConfig_Error(t *testing.T) {
+	caFile := writeFile(t, "ca.crt", newTestCA(t).pem)
+	tests := []struct {
+		name   string
+		caFile string
+		mode   string
+	}{{
+		name:   "invalid mode",
+		caFile: caFile,
+		mode:   "Sometimes",
+	}, {
+		name:   "missing CA bundle",
+		caFile: filepath.Join(t.TempDir(), "missing.crt"),
+	}, {
+		name:   "no certificates in CA bundle",
+		caFile: writeFile(t, "empty.crt", []byte("not a certificate")),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ClientAuthTLSConfig(tc.caFile, tc.mode); err == nil {
+				t.Error("ClientAuthTLSConfig() expected error")
+			}
+		})
+	}
+}
+
+func TestExecuteInterceptors_ClientSubject(t *testing.T) {
+	var got *triggersv1beta1.TriggerContext
+	interceptor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req triggersv1beta1.InterceptorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode interceptor request: %v", err)
+		}
+		got = req.Context
+		_ = json.NewEncoder(w).Encode(&triggersv1beta1.InterceptorResponse{Continue: true})
+	})
+	ci := &triggersv1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "authz"},
+		Spec: triggersv1alpha1.ClusterInterceptorSpec{
+			ClientConfig: triggersv1alpha1.ClientConfig{
+				URL: &apis.URL{Scheme: "http", Host: "authz", Path: "/"},
+			},
+		},
+	}
+	s, _ := getSinkAssets(t, test.Resources{ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{ci}}, "my-el", interceptor)
+
+	u, _ := url.Parse("https://example.com")
+	req := &http.Request{
+		URL:        u,
+		RemoteAddr: "192.30.252.1:52000",
+		TLS: &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "producer"}}}},
+		},
+	}
+	trInt := []*triggersv1beta1.TriggerInterceptor{{Ref: triggersv1beta1.InterceptorRef{Name: "authz"}}}
+	if _, _, _, err := s.ExecuteInterceptors(trInt, req, []byte(`{}`), s.Logger, eventID, "trigger", namespace, map[string]interface{}{}, nil); err != nil {
+		t.Fatalf("ExecuteInterceptors() unexpected error: %v", err)
+	}
+	if got == nil || got.ClientSubject != "CN=producer" || got.ClientIP != "192.30.252.1" {
+		t.Errorf("interceptor received context %+v, want client subject CN=producer and client IP 192.30.252.1", got)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/tektoncd/triggers/pkg/template"
+)
+
+// isCloudEvent returns true if the request carries a CloudEvent, either in binary
+// content mode or in structured content mode.
+func isCloudEvent(request *http.Request) bool {
+	if request.Header.Get(template.CloudEventHeaderPrefix+"Specversion") != "" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return mediaType == cloudevents.ApplicationCloudEventsJSON
+}
+
+// normalizeCloudEvent converts a CloudEvent received in binary or structured content
+// mode into the form Triggers processes: its attributes are set as Ce- headers on the
+// request, and the returned body is its data as JSON. Data that is not JSON is
+// returned as a JSON string, encoded as base64 if it is binary. If the request does
+// not carry a CloudEvent, body is returned unchanged.
+func normalizeCloudEvent(request *http.Request, body []byte) ([]byte, error) {
+	if !isCloudEvent(request) {
+		return body, nil
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	msg := cehttp.NewMessageFromHttpRequest(request)
+	defer msg.Finish(nil)
+	if msg.ReadEncoding() == binding.EncodingUnknown {
+		return body, nil
+	}
+	e, err := binding.ToEvent(request.Context(), msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CloudEvent: %w", err)
+	}
+	if err := e.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid CloudEvent: %w", err)
+	}
+
+	for k := range request.Header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(k), template.CloudEventHeaderPrefix) {
+			request.Header.Del(k)
+		}
+	}
+	attrs := map[string]interface{}{
+		"specversion":     e.SpecVersion(),
+		"id":              e.ID(),
+		"source":          e.Source(),
+		"type":            e.Type(),
+		"subject":         e.Subject(),
+		"dataschema":      e.DataSchema(),
+		"datacontenttype": e.DataContentType(),
+	}
+	if !e.Time().IsZero() {
+		attrs["time"] = e.Time()
+	}
+	for k, v := range e.Extensions() {
+		attrs[k] = v
+	}
+	for k, v := range attrs {
+		s, err := types.Format(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CloudEvent attribute %s: %w", k, err)
+		}
+		if s != "" {
+			request.Header.Set(template.CloudEventHeaderPrefix+k, s)
+		}
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	data, err := cloudEventData(e)
+	if err != nil {
+		return nil, err
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// cloudEventData returns the data of e as JSON.
+func cloudEventData(e *cloudevents.Event) ([]byte, error) {
+	data := e.Data()
+	if len(data) == 0 {
+		return []byte("{}"), nil
+	}
+	if !e.DataBase64 && isJSONMediaType(e.DataMediaType()) && json.Valid(data) {
+		return data, nil
+	}
+	if !e.DataBase64 && utf8.Valid(data) {
+		return json.Marshal(string(data))
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(data))
+}
+
+// isJSONMediaType returns true if data of the given media type is JSON. Data
+// without a media type is assumed to be JSON.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "" ||
+		mediaType == "application/json" ||
+		mediaType == "text/json" ||
+		strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/template"
+)
+
+func TestNormalizeCloudEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		header    http.Header
+		body      string
+		wantBody  string
+		wantAttrs map[string]interface{}
+	}{{
+		name:      "not a CloudEvent",
+		header:    http.Header{"Content-Type": {"application/json"}},
+		body:      `{"a":"b"}`,
+		wantBody:  `{"a":"b"}`,
+		wantAttrs: map[string]interface{}{},
+	}, {
+		name: "binary content mode with JSON data",
+		header: http.Header{
+			"Content-Type":   {"application/json"},
+			"Ce-Specversion": {"1.0"},
+			"Ce-Id":          {"1"},
+			"Ce-Source":      {"/example"},
+			"Ce-Type":        {"com.example.push"},
+			"Ce-Repository":  {"triggers"},
+		},
+		body:     `{"a":"b"}`,
+		wantBody: `{"a":"b"}`,
+		wantAttrs: map[string]interface{}{
+			"specversion":     "1.0",
+			"id":              "1",
+			"source":          "/example",
+			"type":            "com.example.push",
+			"datacontenttype": "application/json",
+			"repository":      "triggers",
+		},
+	}, {
+		name: "binary content mode with text data",
+		header: http.Header{
+			"Content-Type":   {"text/plain"},
+			"Ce-Specversion": {"1.0"},
+			"Ce-Id":          {"1"},
+			"Ce-Source":      {"/example"},
+			"Ce-Type":        {"com.example.push"},
+		},
+		body:     `hello "world"`,
+		wantBody: `"hello \"world\""`,
+		wantAttrs: map[string]interface{}{
+			"specversion":     "1.0",
+			"id":              "1",
+			"source":          "/example",
+			"type":            "com.example.push",
+			"datacontenttype": "text/plain",
+		},
+	}, {
+		name: "binary content mode without data",
+		header: http.Header{
+			"Ce-Specversion": {"1.0"},
+			"Ce-Id":          {"1"},
+			"Ce-Source":      {"/example"},
+			"Ce-Type":        {"com.example.push"},
+		},
+		wantBody: `{}`,
+		wantAttrs: map[string]interface{}{
+			"specversion": "1.0",
+			"id":          "1",
+			"source":      "/example",
+			"type":        "com.example.push",
+		},
+	}, {
+		name:     "structured content mode with JSON data",
+		header:   http.Header{"Content-Type": {"application/cloudevents+json"}},
+		body:     `{"specversion":"1.0","id":"1","source":"/example","type":"com.example.push","subject":"main","time":"2021-06-01T10:00:00Z","datacontenttype":"application/json","data":{"a":"b"}}`,
+		wantBody: `{"a":"b"}`,
+		wantAttrs: map[string]interface{}{
+			"specversion":     "1.0",
+			"id":              "1",
+			"source":          "/example",
+			"type":            "com.example.push",
+			"subject":         "main",
+			"time":            "2021-06-01T10:00:00Z",
+			"datacontenttype": "application/json",
+		},
+	}, {
+		name:     "structured content mode with base64 data",
+		header:   http.Header{"Content-Type": {"application/cloudevents+json; charset=utf-8"}},
+		body:     `{"specversion":"1.0","id":"1","source":"/example","type":"com.example.push","datacontenttype":"application/octet-stream","data_base64":"AAEC"}`,
+		wantBody: `"AAEC"`,
+		wantAttrs: map[string]interface{}{
+			"specversion":     "1.0",
+			"id":              "1",
+			"source":          "/example",
+			"type":            "com.example.push",
+			"datacontenttype": "application/octet-stream",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader([]byte(tc.body)))
+			req.Header = tc.header
+			got, err := normalizeCloudEvent(req, []byte(tc.body))
+			if err != nil {
+				t.Fatalf("normalizeCloudEvent() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantBody, string(got)); diff != "" {
+				t.Errorf("normalizeCloudEvent() body -want/+got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAttrs, template.CloudEventAttributes(req.Header)); diff != "" {
+				t.Errorf("normalizeCloudEvent() attributes -want/+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeCloudEvent_Error(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		header http.Header
+		body   string
+	}{{
+		name: "binary content mode without id",
+		header: http.Header{
+			"Ce-Specversion": {"1.0"},
+			"Ce-Source":      {"/example"},
+			"Ce-Type":        {"com.example.push"},
+		},
+	}, {
+		name:   "structured content mode with malformed JSON",
+		header: http.Header{"Content-Type": {"application/cloudevents+json"}},
+		body:   `{"specversion":`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader([]byte(tc.body)))
+			req.Header = tc.header
+			if _, err := normalizeCloudEvent(req, []byte(tc.body)); err == nil {
+				t.Error("normalizeCloudEvent() did not return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tidwall/sjson"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// concurrencyKeyLabelKey labels the resources created for a concurrency group with
+// the ID of the group.
+const concurrencyKeyLabelKey = triggers.GroupName + "/concurrency-key"
+
+// pipelineRunLabelKey is the label that Tekton Pipelines sets on the TaskRuns of a PipelineRun.
+const pipelineRunLabelKey = "tekton.dev/pipelineRun"
+
+// The resources of the runs whose concurrency is controlled.
+var (
+	pipelineRunsGVR = pipelinev1beta1.SchemeGroupVersion.WithResource("pipelineruns")
+	taskRunsGVR     = pipelinev1beta1.SchemeGroupVersion.WithResource("taskruns")
+)
+
+// concurrencyGroup is the group of the runs created by a Trigger for events with the same key.
+type concurrencyGroup struct {
+	// id identifies the group, and is the value of the concurrency key label of its resources.
+	id     string
+	policy triggersv1.ConcurrencyPolicy
+	// namespaces are the namespaces the runs of the group are created in.
+	namespaces []string
+}
+
+// ConcurrencyGroups controls the PipelineRuns and TaskRuns of the concurrency groups of
+// Triggers. The events of a group are processed one at a time by an EventListener
+// replica. Replicas do not coordinate, so the policy of a group only holds within a
+// single replica.
+type ConcurrencyGroups struct {
+	Logger *zap.SugaredLogger
+
+	mu sync.Mutex
+	// locks serializes the processing of the events of each group.
+	locks map[string]*groupLock
+}
+
+type groupLock struct {
+	sync.Mutex
+	// refs is the number of events that hold or wait for the lock.
+	refs int
+}
+
+// NewConcurrencyGroups returns ConcurrencyGroups that log with logger.
+func NewConcurrencyGroups(logger *zap.SugaredLogger) *ConcurrencyGroups {
+	return &ConcurrencyGroups{
+		Logger: logger,
+		locks:  map[string]*groupLock{},
+	}
+}
+
+// newConcurrencyGroup returns the concurrency group of the event for the Trigger t, whose
+// resolved resources are resources.
+func newConcurrencyGroup(t triggersv1.Trigger, key string, resources []json.RawMessage) *concurrencyGroup {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", t.Namespace, t.Name, key)))
+	g := &concurrencyGroup{
+		id:     fmt.Sprintf("%x", sum[:16]),
+		policy: t.Spec.Concurrency.Policy,
+	}
+	if g.policy == "" {
+		g.policy = triggersv1.ConcurrencyPolicyCancelInProgress
+	}
+	seen := map[string]bool{}
+	for _, rr := range resources {
+		run, ok := parseRun(rr)
+		if !ok {
+			continue
+		}
+		ns := run.Metadata.Namespace
+		if ns == "" {
+			ns = t.Namespace
+		}
+		if !seen[ns] {
+			seen[ns] = true
+			g.namespaces = append(g.namespaces, ns)
+		}
+	}
+	return g
+}
+
+// lock waits until no other event of the group with id is processed, and returns the
+// function that lets the next event be processed.
+func (c *ConcurrencyGroups) lock(id string) func() {
+	c.mu.Lock()
+	l, ok := c.locks[id]
+	if !ok {
+		l = &groupLock{}
+		c.locks[id] = l
+	}
+	l.refs++
+	c.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(c.locks, id)
+		}
+	}
+}
+
+// prepare labels the resources of an event with the group g, and returns the resources
+// to create for the event according to the policy of the group. It returns false if no
+// resources are created for the event.
+func (c *ConcurrencyGroups) prepare(ctx context.Context, g *concurrencyGroup, dc dynamic.Interface, resources []json.RawMessage) ([]json.RawMessage, bool, error) {
+	labelPath := "metadata.labels." + strings.ReplaceAll(concurrencyKeyLabelKey, ".", `\.`)
+	prepared := make([]json.RawMessage, 0, len(resources))
+	for _, rr := range resources {
+		rr, err := sjson.SetBytes(rr, labelPath, g.id)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to label resource with concurrency key: %w", err)
+		}
+		prepared = append(prepared, rr)
+	}
+	if g.policy == triggersv1.ConcurrencyPolicyCancelInProgress {
+		return prepared, true, nil
+	}
+
+	runs, err := g.runs(ctx, dc)
+	if err != nil {
+		return nil, false, err
+	}
+	inProgress := false
+	for _, run := range runs {
+		inProgress = inProgress || !isDone(run)
+	}
+	if !inProgress {
+		return prepared, true, nil
+	}
+	if g.policy == triggersv1.ConcurrencyPolicySkip {
+		return nil, false, nil
+	}
+	// The PipelineRuns are queued behind the runs in progress.
+	for i, rr := range prepared {
+		if run, ok := parseRun(rr); !ok || run.Kind != "PipelineRun" {
+			continue
+		}
+		if prepared[i], err = sjson.SetBytes(rr, "spec.status", pipelinev1beta1.PipelineRunSpecStatusPending); err != nil {
+			return nil, false, fmt.Errorf("failed to queue PipelineRun: %w", err)
+		}
+	}
+	return prepared, true, nil
+}
+
+// finish applies the policy of the group g once the resources of an event were created.
+// Runs of the group that are in progress are cancelled. Queued PipelineRuns are started
+// by StartQueuedRuns.
+func (c *ConcurrencyGroups) finish(ctx context.Context, g *concurrencyGroup, dc dynamic.Interface, created []*unstructured.Unstructured, log *zap.SugaredLogger) {
+	if g.policy == triggersv1.ConcurrencyPolicyCancelInProgress {
+		createdRuns := map[string]bool{}
+		for _, obj := range created {
+			createdRuns[obj.GetNamespace()+"/"+obj.GetName()] = true
+		}
+		runs, err := g.runs(ctx, dc)
+		if err != nil {
+			log.Errorf("failed to list runs of concurrency group %s: %v", g.id, err)
+			return
+		}
+		for _, run := range runs {
+			if createdRuns[run.GetNamespace()+"/"+run.GetName()] || isDone(run) || isCancelled(run) {
+				continue
+			}
+			if err := cancelRun(ctx, dc, run); err != nil {
+				log.Errorf("failed to cancel %s %s/%s: %v", run.GetKind(), run.GetNamespace(), run.GetName(), err)
+				continue
+			}
+			log.Infof("cancelled %s %s/%s superseded by a newer event", run.GetKind(), run.GetNamespace(), run.GetName())
+		}
+	}
+}
+
+// StartQueuedRuns starts the next queued PipelineRun of the concurrency groups of the
+// Triggers of the EventListener whose runs completed every interval until ctx is done.
+func (r Sink) StartQueuedRuns(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.releaseQueuedRuns(ctx)
+		}
+	}
+}
+
+// releaseQueuedRuns starts the next queued PipelineRun of each concurrency group whose
+// runs completed. The queued PipelineRuns are found in the cluster, so that the runs
+// queued by EventListener replicas that restarted are started too.
+func (r Sink) releaseQueuedRuns(ctx context.Context) {
+	el, err := r.EventListenerLister.EventListeners(r.EventListenerNamespace).Get(r.EventListenerName)
+	if err != nil {
+		r.Logger.Errorf("failed to get EventListener %s: %v", r.EventListenerName, err)
+		return
+	}
+	ts, err := r.eventListenerTriggers(el)
+	if err != nil {
+		r.Logger.Errorf("failed to start queued runs: %v", err)
+		return
+	}
+	for _, t := range ts {
+		if t.Spec.Concurrency == nil || t.Spec.Concurrency.Policy != triggersv1.ConcurrencyPolicyQueue {
+			continue
+		}
+		log := r.Logger.With(zap.String(triggers.TriggerLabelKey, t.Name))
+		groups, dc, err := r.queuedGroups(ctx, t, log)
+		if err != nil {
+			log.Errorf("failed to find queued runs: %v", err)
+			continue
+		}
+		for _, g := range groups {
+			unlock := r.ConcurrencyGroups.lock(g.id)
+			if err := r.ConcurrencyGroups.release(ctx, g, dc); err != nil {
+				log.Errorf("failed to start queued runs of concurrency group %s: %v", g.id, err)
+			}
+			unlock()
+		}
+	}
+}
+
+// queuedGroups returns the concurrency groups of the Trigger t that have queued
+// PipelineRuns, and the client to start them with. The PipelineRuns are found by
+// their labels in the namespaces of the run templates of the Trigger.
+func (r Sink) queuedGroups(ctx context.Context, t *triggersv1.Trigger, log *zap.SugaredLogger) ([]*concurrencyGroup, dynamic.Interface, error) {
+	kinds, err := r.templateKinds(t, func(rr json.RawMessage) bool {
+		_, ok := parseRun(rr)
+		return ok
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var namespaces []string
+	for _, k := range kinds {
+		for _, ns := range k.namespaces {
+			if !containsString(namespaces, ns) {
+				namespaces = append(namespaces, ns)
+			}
+		}
+	}
+	if len(namespaces) == 0 {
+		return nil, nil, nil
+	}
+	dc, err := r.dynamicClientFor(t.Spec.ServiceAccountName, t.Namespace, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s,%s=%s,%s=%s", concurrencyKeyLabelKey,
+		triggers.GroupName+triggers.EventListenerLabelKey, r.EventListenerName,
+		triggers.GroupName+triggers.TriggerLabelKey, t.Name)}
+	var groups []*concurrencyGroup
+	seen := map[string]bool{}
+	for _, ns := range namespaces {
+		list, err := dc.Resource(pipelineRunsGVR).Namespace(ns).List(ctx, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pipelineruns: %w", err)
+		}
+		for i := range list.Items {
+			run := &list.Items[i]
+			id := run.GetLabels()[concurrencyKeyLabelKey]
+			if !isPending(run) || seen[id] {
+				continue
+			}
+			seen[id] = true
+			groups = append(groups, &concurrencyGroup{id: id, policy: triggersv1.ConcurrencyPolicyQueue, namespaces: namespaces})
+		}
+	}
+	return groups, dc, nil
+}
+
+// release starts the oldest queued PipelineRun of the group g if none of its runs are
+// running.
+func (c *ConcurrencyGroups) release(ctx context.Context, g *concurrencyGroup, dc dynamic.Interface) error {
+	runs, err := g.runs(ctx, dc)
+	if err != nil {
+		return err
+	}
+	var pending []*unstructured.Unstructured
+	for _, run := range runs {
+		switch {
+		case isDone(run):
+		case isPending(run):
+			pending = append(pending, run)
+		default:
+			// A run of the group is still running.
+			return nil
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		ti, tj := pending[i].GetCreationTimestamp(), pending[j].GetCreationTimestamp()
+		return ti.Before(&tj)
+	})
+	next := pending[0]
+	patch := []byte(`{"spec":{"status":null}}`)
+	if _, err := dc.Resource(pipelineRunsGVR).Namespace(next.GetNamespace()).Patch(ctx, next.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	c.Logger.Infof("started queued PipelineRun %s/%s of concurrency group %s", next.GetNamespace(), next.GetName(), g.id)
+	return nil
+}
+
+// runs returns the PipelineRuns and TaskRuns of the group g. The TaskRuns of PipelineRuns
+// are controlled with their PipelineRun, and are not returned.
+func (g *concurrencyGroup) runs(ctx context.Context, dc dynamic.Interface) ([]*unstructured.Unstructured, error) {
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", concurrencyKeyLabelKey, g.id)}
+	var runs []*unstructured.Unstructured
+	for _, ns := range g.namespaces {
+		for _, gvr := range []schema.GroupVersionResource{pipelineRunsGVR, taskRunsGVR} {
+			list, err := dc.Resource(gvr).Namespace(ns).List(ctx, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+			}
+			for i := range list.Items {
+				run := &list.Items[i]
+				if _, ok := run.GetLabels()[pipelineRunLabelKey]; ok && gvr == taskRunsGVR {
+					continue
+				}
+				runs = append(runs, run)
+			}
+		}
+	}
+	return runs, nil
+}
+
+// cancelRun cancels the PipelineRun or TaskRun run.
+func cancelRun(ctx context.Context, dc dynamic.Interface, run *unstructured.Unstructured) error {
+	gvr, status := pipelineRunsGVR, pipelinev1beta1.PipelineRunSpecStatusCancelled
+	if run.GetKind() == "TaskRun" {
+		gvr, status = taskRunsGVR, pipelinev1beta1.TaskRunSpecStatusCancelled
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"status":%q}}`, status))
+	_, err := dc.Resource(gvr).Namespace(run.GetNamespace()).Patch(ctx, run.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// templateResource holds the fields of a resource template that identify its resource.
+type templateResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+// parseRun returns the resource rr, and true if it is a Tekton PipelineRun or TaskRun.
+func parseRun(rr json.RawMessage) (templateResource, bool) {
+	var run templateResource
+	if err := json.Unmarshal(rr, &run); err != nil {
+		return run, false
+	}
+	isTekton := strings.HasPrefix(run.APIVersion, pipelinev1beta1.SchemeGroupVersion.Group+"/")
+	return run, isTekton && (run.Kind == "PipelineRun" || run.Kind == "TaskRun")
+}
+
+// isDone returns true if the run completed, successfully or not.
+func isDone(run *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(run.Object, "status", "conditions")
+	for _, c := range conditions {
+		c, ok := c.(map[string]interface{})
+		if ok && c["type"] == "Succeeded" {
+			return c["status"] == "True" || c["status"] == "False"
+		}
+	}
+	return false
+}
+
+// isPending returns true if the run is a queued PipelineRun.
+func isPending(run *unstructured.Unstructured) bool {
+	status, _, _ := unstructured.NestedString(run.Object, "spec", "status")
+	return status == pipelinev1beta1.PipelineRunSpecStatusPending
+}
+
+// isCancelled returns true if the run was cancelled or stopped.
+func isCancelled(run *unstructured.Unstructured) bool {
+	status, _, _ := unstructured.NestedString(run.Object, "spec", "status")
+	switch status {
+	case pipelinev1beta1.PipelineRunSpecStatusCancelled,
+		pipelinev1beta1.PipelineRunSpecStatusCancelledDeprecated,
+		pipelinev1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		pipelinev1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		string(pipelinev1beta1.TaskRunSpecStatusCancelled):
+		return true
+	}
+	return false
+}
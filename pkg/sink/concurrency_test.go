@@ -0,0 +1,343 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"knative.dev/pkg/ptr"
+)
+
+// concurrencyTrigger returns a Trigger with the concurrency policy.
+func concurrencyTrigger(policy triggersv1beta1.ConcurrencyPolicy) triggersv1beta1.Trigger {
+	return triggersv1beta1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: namespace},
+		Spec: triggersv1beta1.TriggerSpec{
+			Concurrency: &triggersv1beta1.Concurrency{Key: "$(body.number)", Policy: policy},
+		},
+	}
+}
+
+// run returns a PipelineRun or TaskRun of the concurrency group with id. The run is
+// done if succeeded is "True" or "False".
+func run(kind, name, id, succeeded string, labels map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/v1beta1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+	}}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[concurrencyKeyLabelKey] = id
+	u.SetLabels(labels)
+	if succeeded != "" {
+		_ = unstructured.SetNestedSlice(u.Object, []interface{}{map[string]interface{}{"type": "Succeeded", "status": succeeded}}, "status", "conditions")
+	}
+	return u
+}
+
+func newRunsClient(objects ...runtime.Object) *fakedynamic.FakeDynamicClient {
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		pipelineRunsGVR: "PipelineRunList",
+		taskRunsGVR:     "TaskRunList",
+	}, objects...)
+}
+
+// specStatus returns the spec.status of the run with name.
+func specStatus(t *testing.T, dc *fakedynamic.FakeDynamicClient, kind, name string) string {
+	t.Helper()
+	gvr := pipelineRunsGVR
+	if kind == "TaskRun" {
+		gvr = taskRunsGVR
+	}
+	u, err := dc.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, _, _ := unstructured.NestedString(u.Object, "spec", "status")
+	return status
+}
+
+func TestConcurrencyGroups_CancelInProgress(t *testing.T) {
+	newRun := json.RawMessage(`{"apiVersion":"tekton.dev/v1beta1","kind":"PipelineRun","metadata":{"name":"new"}}`)
+	g := newConcurrencyGroup(concurrencyTrigger(""), "42", []json.RawMessage{newRun})
+	dc := newRunsClient(
+		run("PipelineRun", "running", g.id, "Unknown", nil),
+		run("PipelineRun", "done", g.id, "True", nil),
+		run("TaskRun", "running-task", g.id, "", nil),
+		run("TaskRun", "running-pipeline-task", g.id, "", map[string]string{pipelineRunLabelKey: "running"}),
+		run("PipelineRun", "other-group", "other", "", nil),
+	)
+	c := NewConcurrencyGroups(zaptest.NewLogger(t).Sugar())
+	ctx := context.Background()
+
+	resources, create, err := c.prepare(ctx, g, dc, []json.RawMessage{newRun})
+	if err != nil || !create {
+		t.Fatalf("prepare() = %t, %v, want the resources to be created", create, err)
+	}
+	created := &unstructured.Unstructured{}
+	if err := created.UnmarshalJSON(resources[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got := created.GetLabels()[concurrencyKeyLabelKey]; got != g.id {
+		t.Errorf("resource labelled with concurrency key %q, want %q", got, g.id)
+	}
+	created.SetNamespace(namespace)
+	created, err = dc.Resource(pipelineRunsGVR).Namespace(namespace).Create(ctx, created, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.finish(ctx, g, dc, []*unstructured.Unstructured{created}, c.Logger)
+
+	for _, tc := range []struct {
+		kind, name, want string
+	}{
+		{"PipelineRun", "new", ""},
+		{"PipelineRun", "running", "Cancelled"},
+		{"PipelineRun", "done", ""},
+		{"TaskRun", "running-task", "TaskRunCancelled"},
+		{"TaskRun", "running-pipeline-task", ""},
+		{"PipelineRun", "other-group", ""},
+	} {
+		if got := specStatus(t, dc, tc.kind, tc.name); got != tc.want {
+			t.Errorf("%s %s has status %q, want %q", tc.kind, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestConcurrencyGroups_Skip(t *testing.T) {
+	newRun := json.RawMessage(`{"apiVersion":"tekton.dev/v1beta1","kind":"PipelineRun","metadata":{"name":"new"}}`)
+	g := newConcurrencyGroup(concurrencyTrigger(triggersv1beta1.ConcurrencyPolicySkip), "42", []json.RawMessage{newRun})
+	tests := []struct {
+		name       string
+		runs       []runtime.Object
+		wantCreate bool
+	}{{
+		name:       "no runs",
+		wantCreate: true,
+	}, {
+		name:       "completed runs",
+		runs:       []runtime.Object{run("PipelineRun", "done", g.id, "False", nil)},
+		wantCreate: true,
+	}, {
+		name: "run in progress",
+		runs: []runtime.Object{run("PipelineRun", "running", g.id, "Unknown", nil)},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewConcurrencyGroups(zaptest.NewLogger(t).Sugar())
+			_, create, err := c.prepare(context.Background(), g, newRunsClient(tc.runs...), []json.RawMessage{newRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if create != tc.wantCreate {
+				t.Errorf("prepare() create = %t, want %t", create, tc.wantCreate)
+			}
+		})
+	}
+}
+
+func TestReleaseQueuedRuns(t *testing.T) {
+	ctx := context.Background()
+	newRun := func(name string) json.RawMessage {
+		return json.RawMessage(fmt.Sprintf(`{"apiVersion":"tekton.dev/v1beta1","kind":"PipelineRun","metadata":{"name":%q,"namespace":%q}}`, name, namespace))
+	}
+	trigger := concurrencyTrigger(triggersv1beta1.ConcurrencyPolicyQueue)
+	el := &triggersv1beta1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "queue-el",
+			Namespace: namespace,
+			UID:       types.UID(elUID),
+		},
+		Spec: triggersv1beta1.EventListenerSpec{
+			Triggers: []triggersv1beta1.EventListenerTrigger{{
+				Name: trigger.Name,
+				Template: &triggersv1beta1.EventListenerTemplate{
+					Spec: &triggersv1beta1.TriggerTemplateSpec{
+						ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+							RawExtension: runtime.RawExtension{Raw: newRun("$(tt.params.name)")},
+						}},
+					},
+				},
+				Concurrency: trigger.Spec.Concurrency,
+			}},
+		},
+	}
+	createdLabels := func() map[string]string {
+		return map[string]string{
+			"triggers.tekton.dev/eventlistener": el.Name,
+			"triggers.tekton.dev/trigger":       trigger.Name,
+		}
+	}
+	g := newConcurrencyGroup(trigger, "42", []json.RawMessage{newRun("")})
+	other := newConcurrencyGroup(trigger, "43", []json.RawMessage{newRun("")})
+	queued := run("PipelineRun", "other-queued", other.id, "", createdLabels())
+	queued.SetCreationTimestamp(metav1.Unix(0, 0))
+	_ = unstructured.SetNestedField(queued.Object, "PipelineRunPending", "spec", "status")
+	dc := newRunsClient(run("PipelineRun", "running", g.id, "Unknown", createdLabels()), queued)
+
+	s, _ := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1beta1.EventListener{el}}, el.Name, nil)
+	s.DynamicClient = dc
+	s.ConcurrencyGroups = NewConcurrencyGroups(s.Logger)
+	c := s.ConcurrencyGroups
+
+	// Two events are queued behind the run in progress.
+	for i, name := range []string{"first", "second"} {
+		resources, create, err := c.prepare(ctx, g, dc, []json.RawMessage{newRun(name)})
+		if err != nil || !create {
+			t.Fatalf("prepare() = %t, %v, want the resources to be created", create, err)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(resources[0]); err != nil {
+			t.Fatal(err)
+		}
+		labels := obj.GetLabels()
+		for k, v := range createdLabels() {
+			labels[k] = v
+		}
+		obj.SetLabels(labels)
+		obj.SetCreationTimestamp(metav1.Unix(int64(i), 0))
+		if obj, err = dc.Resource(pipelineRunsGVR).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		c.finish(ctx, g, dc, []*unstructured.Unstructured{obj}, c.Logger)
+	}
+	statuses := func() []string {
+		return []string{specStatus(t, dc, "PipelineRun", "first"), specStatus(t, dc, "PipelineRun", "second")}
+	}
+	complete := func(name string) {
+		t.Helper()
+		patch := []byte(`{"status":{"conditions":[{"type":"Succeeded","status":"True"}]}}`)
+		if _, err := dc.Resource(pipelineRunsGVR).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Runs queued before the EventListener started are found in the cluster.
+	s.releaseQueuedRuns(ctx)
+	if got := specStatus(t, dc, "PipelineRun", "other-queued"); got != "" {
+		t.Errorf("expected the queued run of a group without runs in progress to start, got status %q", got)
+	}
+	if diff := cmp.Diff([]string{"PipelineRunPending", "PipelineRunPending"}, statuses()); diff != "" {
+		t.Errorf("runs started while a run is in progress -want,+got: %s", diff)
+	}
+
+	complete("running")
+	s.releaseQueuedRuns(ctx)
+	if diff := cmp.Diff([]string{"", "PipelineRunPending"}, statuses()); diff != "" {
+		t.Errorf("expected the oldest queued run to start -want,+got: %s", diff)
+	}
+
+	complete("first")
+	s.releaseQueuedRuns(ctx)
+	if diff := cmp.Diff([]string{"", ""}, statuses()); diff != "" {
+		t.Errorf("expected the next queued run to start -want,+got: %s", diff)
+	}
+}
+
+func TestHandleEvent_ConcurrencySkip(t *testing.T) {
+	trigger := concurrencyTrigger(triggersv1beta1.ConcurrencyPolicySkip)
+	el := &triggersv1beta1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "concurrency-el",
+			Namespace: namespace,
+			UID:       types.UID(elUID),
+		},
+		Spec: triggersv1beta1.EventListenerSpec{
+			Triggers: []triggersv1beta1.EventListenerTrigger{{
+				Name: trigger.Name,
+				Template: &triggersv1beta1.EventListenerTemplate{
+					Spec: &triggersv1beta1.TriggerTemplateSpec{
+						Params: []triggersv1beta1.ParamSpec{
+							{Name: "url", Default: ptr.String("testurl")},
+							{Name: "revision", Default: ptr.String("testrevision")},
+							{Name: "name", Default: ptr.String("my-taskrun")},
+							{Name: "app", Default: ptr.String("triggers")},
+							{Name: "type", Default: ptr.String("bar")},
+						},
+						ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+							RawExtension: trResourceTemplate(t),
+						}},
+					},
+				},
+				Concurrency: trigger.Spec.Concurrency,
+			}},
+		},
+	}
+	g := newConcurrencyGroup(trigger, "42", []json.RawMessage{trResourceTemplate(t).Raw})
+
+	for _, tc := range []struct {
+		name        string
+		runs        []runtime.Object
+		wantSkipped bool
+	}{{
+		name: "no runs in progress",
+	}, {
+		name:        "run in progress",
+		runs:        []runtime.Object{run("TaskRun", "running", g.id, "Unknown", nil)},
+		wantSkipped: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _ := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1beta1.EventListener{el}}, el.Name, nil)
+			dc := newRunsClient(tc.runs...)
+			s.DynamicClient = dc
+			s.ConcurrencyGroups = NewConcurrencyGroups(s.Logger)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"number":42}`)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(SyncResponseHeader, "true")
+			resp := httptest.NewRecorder()
+			s.HandleEvent(resp, req)
+			s.WGProcessTriggers.Wait()
+
+			var body Response
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response %q: %v", resp.Body.String(), err)
+			}
+			if len(body.Triggers) != 1 {
+				t.Fatalf("expected the result of one Trigger, got %+v", body.Triggers)
+			}
+			got := body.Triggers[0]
+			if got.Skipped != tc.wantSkipped {
+				t.Errorf("Trigger skipped = %t, want %t: %+v", got.Skipped, tc.wantSkipped, got)
+			}
+			if wantCreated := !tc.wantSkipped; (len(got.Resources) == 1) != wantCreated {
+				t.Errorf("created resources %+v, want created %t", got.Resources, wantCreated)
+			}
+		})
+	}
+}
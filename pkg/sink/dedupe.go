@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultDeduplicationTTL is how long keys are remembered if Deduplication does not set a TTL.
+	defaultDeduplicationTTL = time.Hour
+
+	// forgetDeliveryTimeout bounds the removal of the record of an event whose processing
+	// failed. The record is removed even if processing failed because it timed out.
+	forgetDeliveryTimeout = 30 * time.Second
+
+	// deduplicationKeyAnnotation records the key of the event a Lease was created for.
+	deduplicationKeyAnnotation = triggers.GroupName + "/deduplication-key"
+
+	// deduplicationLabelKey marks the Leases created to deduplicate events.
+	deduplicationLabelKey = triggers.GroupName + "/deduplication"
+)
+
+// Deduplicator records the keys of delivered events so that redelivered events
+// can be recognized for as long as the TTL of their key.
+type Deduplicator struct {
+	KubeClientSet          kubernetes.Interface
+	EventListenerName      string
+	EventListenerNamespace string
+	Logger                 *zap.SugaredLogger
+
+	mu sync.Mutex
+	// seen maps the keys recorded in memory to the time they expire.
+	seen map[string]time.Time
+	// usesLeases is true once a key was recorded as a Lease, so that expired Leases
+	// are only swept by EventListeners that are allowed to manage them.
+	usesLeases bool
+}
+
+// NewDeduplicator returns a Deduplicator for the given EventListener.
+func NewDeduplicator(kubeClient kubernetes.Interface, elName, elNamespace string, logger *zap.SugaredLogger) *Deduplicator {
+	return &Deduplicator{
+		KubeClientSet:          kubeClient,
+		EventListenerName:      elName,
+		EventListenerNamespace: elNamespace,
+		Logger:                 logger,
+		seen:                   map[string]time.Time{},
+	}
+}
+
+// MarkDelivered records that the event with the given key was delivered, and
+// returns true if it was already delivered within the TTL of dedup.
+func (d *Deduplicator) MarkDelivered(ctx context.Context, dedup *triggersv1.Deduplication, key string) (bool, error) {
+	ttl := defaultDeduplicationTTL
+	if dedup.TTL != nil {
+		ttl = dedup.TTL.Duration
+	}
+	if dedup.Store == triggersv1.DeduplicationStoreLease {
+		return d.markLease(ctx, key, ttl)
+	}
+	return d.markMemory(key, ttl, time.Now()), nil
+}
+
+// Forget removes the record of the event with the given key, so that it is
+// processed if it is delivered again.
+func (d *Deduplicator) Forget(ctx context.Context, dedup *triggersv1.Deduplication, key string) error {
+	if dedup.Store == triggersv1.DeduplicationStoreLease {
+		err := d.KubeClientSet.CoordinationV1().Leases(d.EventListenerNamespace).Delete(ctx, d.leaseName(key), metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seen, key)
+	return nil
+}
+
+func (d *Deduplicator) markMemory(key string, ttl time.Duration, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if expiry, ok := d.seen[key]; ok && now.Before(expiry) {
+		return true
+	}
+	d.seen[key] = now.Add(ttl)
+	return false
+}
+
+func (d *Deduplicator) markLease(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	d.mu.Lock()
+	d.usesLeases = true
+	d.mu.Unlock()
+
+	leases := d.KubeClientSet.CoordinationV1().Leases(d.EventListenerNamespace)
+	now := metav1.NewMicroTime(time.Now())
+	seconds := int32(math.Ceil(ttl.Seconds()))
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.leaseName(key),
+			Namespace: d.EventListenerNamespace,
+			Labels: map[string]string{
+				triggers.GroupName + triggers.EventListenerLabelKey: d.EventListenerName,
+				deduplicationLabelKey:                               "true",
+			},
+			Annotations: map[string]string{
+				deduplicationKeyAnnotation: key,
+			},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			AcquireTime:          &now,
+			RenewTime:            &now,
+			LeaseDurationSeconds: &seconds,
+		},
+	}
+	_, err := leases.Create(ctx, lease, metav1.CreateOptions{})
+	if err == nil {
+		return false, nil
+	}
+	if !kerrors.IsAlreadyExists(err) {
+		return false, err
+	}
+
+	existing, err := leases.Get(ctx, lease.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if !leaseExpired(existing, now.Time) {
+		return true, nil
+	}
+	// The event was delivered longer than the TTL ago, so record it again.
+	existing.Spec = lease.Spec
+	if _, err := leases.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		if kerrors.IsConflict(err) {
+			// Another replica recorded the same event in the meantime.
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+// leaseName returns the name of the Lease that records the event with the given key.
+func (d *Deduplicator) leaseName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s-%x", d.EventListenerName, sum[:16])
+}
+
+// leaseExpired returns true if the TTL of the Lease has passed at now.
+func leaseExpired(l *coordinationv1.Lease, now time.Time) bool {
+	if l.Spec.RenewTime == nil || l.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := l.Spec.RenewTime.Add(time.Duration(*l.Spec.LeaseDurationSeconds) * time.Second)
+	return !now.Before(expiry)
+}
+
+// Start removes expired keys every interval until ctx is done.
+func (d *Deduplicator) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if usesLeases := d.sweepMemory(now); usesLeases {
+				d.sweepLeases(ctx, now)
+			}
+		}
+	}
+}
+
+// sweepMemory removes the expired keys recorded in memory, and returns true if
+// any keys were recorded as Leases.
+func (d *Deduplicator) sweepMemory(now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, expiry := range d.seen {
+		if !now.Before(expiry) {
+			delete(d.seen, key)
+		}
+	}
+	return d.usesLeases
+}
+
+func (d *Deduplicator) sweepLeases(ctx context.Context, now time.Time) {
+	leases := d.KubeClientSet.CoordinationV1().Leases(d.EventListenerNamespace)
+	list, err := leases.List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=true", triggers.GroupName+triggers.EventListenerLabelKey, d.EventListenerName, deduplicationLabelKey),
+	})
+	if err != nil {
+		d.Logger.Errorf("failed to list deduplication leases: %v", err)
+		return
+	}
+	for i := range list.Items {
+		l := &list.Items[i]
+		if !leaseExpired(l, now) {
+			continue
+		}
+		err := leases.Delete(ctx, l.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &l.ResourceVersion},
+		})
+		if err != nil && !kerrors.IsNotFound(err) && !kerrors.IsConflict(err) {
+			d.Logger.Errorf("failed to delete expired deduplication lease %s: %v", l.Name, err)
+		}
+	}
+}
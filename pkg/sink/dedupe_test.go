@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeduplicator_Memory(t *testing.T) {
+	d := NewDeduplicator(fakekube.NewSimpleClientset(), "my-el", namespace, zaptest.NewLogger(t).Sugar())
+	now := time.Now()
+
+	if d.markMemory("a", time.Minute, now) {
+		t.Error("expected first delivery of a to not be a duplicate")
+	}
+	if !d.markMemory("a", time.Minute, now.Add(30*time.Second)) {
+		t.Error("expected second delivery of a to be a duplicate")
+	}
+	if d.markMemory("b", time.Minute, now) {
+		t.Error("expected first delivery of b to not be a duplicate")
+	}
+	if d.markMemory("a", time.Minute, now.Add(2*time.Minute)) {
+		t.Error("expected delivery of a after its TTL to not be a duplicate")
+	}
+
+	d.sweepMemory(now.Add(90 * time.Second))
+	if _, ok := d.seen["b"]; ok {
+		t.Error("expected expired key b to be swept")
+	}
+	if _, ok := d.seen["a"]; !ok {
+		t.Error("expected key a to not be swept")
+	}
+
+	dedup := &triggersv1beta1.Deduplication{Key: "$(header.X-GitHub-Delivery)"}
+	if err := d.Forget(context.Background(), dedup, "a"); err != nil {
+		t.Fatalf("Forget() unexpected error: %v", err)
+	}
+	if d.markMemory("a", time.Minute, now.Add(2*time.Minute)) {
+		t.Error("expected delivery of a after forgetting it to not be a duplicate")
+	}
+}
+
+func TestDeduplicator_Lease(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fakekube.NewSimpleClientset()
+	d := NewDeduplicator(kubeClient, "my-el", namespace, zaptest.NewLogger(t).Sugar())
+	dedup := &triggersv1beta1.Deduplication{
+		Key:   "$(header.X-GitHub-Delivery)",
+		TTL:   &metav1.Duration{Duration: time.Minute},
+		Store: triggersv1beta1.DeduplicationStoreLease,
+	}
+
+	for _, want := range []bool{false, true} {
+		got, err := d.MarkDelivered(ctx, dedup, "a")
+		if err != nil {
+			t.Fatalf("MarkDelivered() unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("MarkDelivered() = %t, want %t", got, want)
+		}
+	}
+
+	// Another replica shares the Leases.
+	other := NewDeduplicator(kubeClient, "my-el", namespace, zaptest.NewLogger(t).Sugar())
+	if got, err := other.MarkDelivered(ctx, dedup, "a"); err != nil || !got {
+		t.Errorf("MarkDelivered() on other replica = %t, %v, want true", got, err)
+	}
+
+	// Expire the Lease.
+	lease, err := kubeClient.CoordinationV1().Leases(namespace).Get(ctx, d.leaseName("a"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	expired := metav1.NewMicroTime(time.Now().Add(-2 * time.Minute))
+	lease.Spec.RenewTime = &expired
+	if _, err := kubeClient.CoordinationV1().Leases(namespace).Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update lease: %v", err)
+	}
+	d.sweepLeases(ctx, time.Now())
+	if _, err := kubeClient.CoordinationV1().Leases(namespace).Get(ctx, d.leaseName("a"), metav1.GetOptions{}); err == nil {
+		t.Error("expected expired lease to be swept")
+	}
+
+	if got, err := d.MarkDelivered(ctx, dedup, "a"); err != nil || got {
+		t.Errorf("MarkDelivered() after expiry = %t, %v, want false", got, err)
+	}
+	if err := d.Forget(ctx, dedup, "a"); err != nil {
+		t.Fatalf("Forget() unexpected error: %v", err)
+	}
+	if got, err := d.MarkDelivered(ctx, dedup, "a"); err != nil || got {
+		t.Errorf("MarkDelivered() after Forget() = %t, %v, want false", got, err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DryRunHeader can be set to "true" on an incoming request to process the event
+	// without creating any resources. The resources that would have been created are
+	// returned in the response instead.
+	DryRunHeader = "Tekton-Triggers-Dry-Run"
+	// DryRunTokenHeader carries the token of dry run requests to EventListeners
+	// that authorize dry runs with a token.
+	DryRunTokenHeader = "Tekton-Triggers-Dry-Run-Token"
+
+	// dryRunSubresource is the subresource of the EventListener that users must be
+	// allowed to create for dry runs authorized with a SubjectAccessReview.
+	dryRunSubresource = "dryrun"
+)
+
+// dryRunError is the reason a dry run request was refused, along with the HTTP
+// status the request is answered with.
+type dryRunError struct {
+	status int
+	msg    string
+}
+
+func (e *dryRunError) Error() string {
+	return e.msg
+}
+
+// isDryRun returns true if the request asks for the event to be processed without
+// creating any resources.
+func isDryRun(request *http.Request) bool {
+	return strings.EqualFold(request.Header.Get(DryRunHeader), "true")
+}
+
+// authorizeDryRun checks that the dry run request passes every check the EventListener
+// configures for dry runs. On success, the credentials of the request are removed from
+// its header so that they are not passed on to interceptors.
+func (r Sink) authorizeDryRun(ctx context.Context, el *triggersv1.EventListener, request *http.Request) error {
+	dr := el.Spec.DryRun
+	if dr == nil {
+		return &dryRunError{status: http.StatusForbidden, msg: "dry run is not enabled for this EventListener"}
+	}
+	if dr.TokenSecretRef != nil {
+		token := request.Header.Get(DryRunTokenHeader)
+		if token == "" {
+			return &dryRunError{status: http.StatusUnauthorized, msg: fmt.Sprintf("dry run requires the %s header", DryRunTokenHeader)}
+		}
+		// An EventListener whose token is not loaded yet must not allow any request.
+		if r.DryRunToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(r.DryRunToken)) != 1 {
+			return &dryRunError{status: http.StatusForbidden, msg: "invalid dry run token"}
+		}
+	}
+	if dr.SubjectAccessReview {
+		if err := r.reviewDryRunAccess(ctx, el, request); err != nil {
+			return err
+		}
+		request.Header.Del("Authorization")
+	}
+	request.Header.Del(DryRunTokenHeader)
+	return nil
+}
+
+// reviewDryRunAccess authenticates the bearer token of the request, and checks that
+// its user can create the dryrun subresource of the EventListener.
+func (r Sink) reviewDryRunAccess(ctx context.Context, el *triggersv1.EventListener, request *http.Request) error {
+	auth := request.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return &dryRunError{status: http.StatusUnauthorized, msg: "dry run requires a bearer token"}
+	}
+	tr, err := r.KubeClientSet.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: strings.TrimPrefix(auth, "Bearer ")},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review token: %w", err)
+	}
+	if !tr.Status.Authenticated {
+		return &dryRunError{status: http.StatusUnauthorized, msg: "invalid bearer token"}
+	}
+
+	user := tr.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar, err := r.KubeClientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   el.Namespace,
+				Verb:        "create",
+				Group:       triggersv1.SchemeGroupVersion.Group,
+				Resource:    "eventlisteners",
+				Subresource: dryRunSubresource,
+				Name:        el.Name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review access: %w", err)
+	}
+	if !sar.Status.Allowed {
+		return &dryRunError{status: http.StatusForbidden, msg: fmt.Sprintf("user %q cannot create eventlisteners/%s for EventListener %s", user.Username, dryRunSubresource, el.Name)}
+	}
+	return nil
+}
+
+// dryRunStatus returns the HTTP status that a dry run request that failed
+// authorization with err is answered with.
+func dryRunStatus(err error) int {
+	var dre *dryRunError
+	if errors.As(err, &dre) {
+		return dre.status
+	}
+	return http.StatusInternalServerError
+}
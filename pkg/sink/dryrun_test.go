@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/ptr"
+)
+
+// reviewDryRunUsers makes the TokenReviews of the fake client authenticate the token
+// "alice-token" as alice and "bob-token" as bob, and its SubjectAccessReviews only
+// allow alice to create the dryrun subresource of the EventListener elName.
+func reviewDryRunUsers(kubeClient *fakekube.Clientset, elName string) {
+	kubeClient.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		tr := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+		switch tr.Spec.Token {
+		case "alice-token":
+			tr.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "alice"}}
+		case "bob-token":
+			tr.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "bob"}}
+		}
+		return true, tr, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		sar := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+		ra := sar.Spec.ResourceAttributes
+		sar.Status.Allowed = sar.Spec.User == "alice" && ra != nil && ra.Verb == "create" &&
+			ra.Group == "triggers.tekton.dev" && ra.Resource == "eventlisteners" &&
+			ra.Subresource == "dryrun" && ra.Namespace == namespace && ra.Name == elName
+		return true, sar, nil
+	})
+}
+
+func TestHandleEvent_DryRun(t *testing.T) {
+	elName := "dry-run-el"
+	dryRun := &triggersv1beta1.DryRun{
+		TokenSecretRef:      &triggersv1beta1.SecretRef{SecretName: "dry-run", SecretKey: "token"},
+		SubjectAccessReview: true,
+	}
+	authorized := map[string]string{
+		DryRunHeader:      "true",
+		DryRunTokenHeader: "secret",
+		"Authorization":   "Bearer alice-token",
+	}
+
+	tests := []struct {
+		name       string
+		dryRun     *triggersv1beta1.DryRun
+		header     map[string]string
+		wantStatus int
+	}{{
+		name:       "authorized",
+		dryRun:     dryRun,
+		header:     authorized,
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "only token",
+		dryRun:     &triggersv1beta1.DryRun{TokenSecretRef: dryRun.TokenSecretRef},
+		header:     map[string]string{DryRunHeader: "true", DryRunTokenHeader: "secret"},
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "only subject access review",
+		dryRun:     &triggersv1beta1.DryRun{SubjectAccessReview: true},
+		header:     map[string]string{DryRunHeader: "true", "Authorization": "Bearer alice-token"},
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "dry run not enabled",
+		header:     authorized,
+		wantStatus: http.StatusForbidden,
+	}, {
+		name:       "missing token",
+		dryRun:     dryRun,
+		header:     map[string]string{DryRunHeader: "true", "Authorization": "Bearer alice-token"},
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "wrong token",
+		dryRun:     dryRun,
+		header:     map[string]string{DryRunHeader: "true", DryRunTokenHeader: "guess", "Authorization": "Bearer alice-token"},
+		wantStatus: http.StatusForbidden,
+	}, {
+		name:       "missing bearer token",
+		dryRun:     dryRun,
+		header:     map[string]string{DryRunHeader: "true", DryRunTokenHeader: "secret"},
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "unauthenticated bearer token",
+		dryRun:     dryRun,
+		header:     map[string]string{DryRunHeader: "true", DryRunTokenHeader: "secret", "Authorization": "Bearer mallory-token"},
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "user not allowed",
+		dryRun:     dryRun,
+		header:     map[string]string{DryRunHeader: "true", DryRunTokenHeader: "secret", "Authorization": "Bearer bob-token"},
+		wantStatus: http.StatusForbidden,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resources := test.Resources{
+				EventListeners: []*triggersv1beta1.EventListener{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      elName,
+						Namespace: namespace,
+						UID:       types.UID(elUID),
+					},
+					Spec: triggersv1beta1.EventListenerSpec{
+						DryRun:             tc.dryRun,
+						TriggerInvocations: &triggersv1beta1.TriggerInvocationPolicy{},
+						Triggers: []triggersv1beta1.EventListenerTrigger{{
+							Name: "push-trigger",
+							Interceptors: []*triggersv1beta1.EventInterceptor{{
+								Ref: triggersv1beta1.InterceptorRef{Name: "cel"},
+								Params: []triggersv1beta1.InterceptorParams{{
+									Name:  "filter",
+									Value: test.ToV1JSON(t, "body.action == 'push' && !has(header.Authorization)"),
+								}},
+							}},
+							Bindings: []*triggersv1beta1.EventListenerBinding{
+								{Name: "name", Value: ptr.String("$(body.action)-run")},
+							},
+							Template: &triggersv1beta1.EventListenerTemplate{
+								Spec: &triggersv1beta1.TriggerTemplateSpec{
+									Params: []triggersv1beta1.ParamSpec{
+										{Name: "name"},
+										{Name: "url", Default: ptr.String("testurl")},
+										{Name: "revision", Default: ptr.String("testrevision")},
+										{Name: "app", Default: ptr.String("triggers")},
+										{Name: "type", Default: ptr.String("bar")},
+									},
+									ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+										RawExtension: trResourceTemplate(t),
+									}},
+								},
+							},
+						}},
+					},
+				}},
+				ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{cel},
+			}
+
+			sink, dynamicClient := getSinkAssets(t, resources, elName, nil)
+			sink.DryRunToken = "secret"
+			reviewDryRunUsers(sink.KubeClientSet.(*fakekube.Clientset), elName)
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{"action":"push"}`)))
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error sending request: %s", err)
+			}
+			defer resp.Body.Close()
+			sink.WGProcessTriggers.Wait()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("Status code mismatch: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			var body Response
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(dynamicClient.Actions()) != 0 {
+				t.Errorf("dry run created resources: %v", dynamicClient.Actions())
+			}
+			list, err := sink.TriggersClient.TriggersV1alpha1().TriggerInvocations(namespace).List(req.Context(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list TriggerInvocations: %v", err)
+			}
+			if len(list.Items) != 0 {
+				t.Errorf("dry run recorded TriggerInvocations: %v", list.Items)
+			}
+			if tc.wantStatus != http.StatusOK {
+				if body.ErrorMessage == "" {
+					t.Errorf("expected an error message in the response")
+				}
+				return
+			}
+
+			if !body.DryRun {
+				t.Errorf("expected the response to be marked as a dry run")
+			}
+			if len(body.Triggers) != 1 {
+				t.Fatalf("expected the result of one trigger, got %+v", body.Triggers)
+			}
+			res := body.Triggers[0]
+			if !res.Continue || res.ErrorMessage != "" || len(res.Resources) != 0 {
+				t.Errorf("unexpected trigger result: %+v", res)
+			}
+			if len(res.RenderedResources) != 1 {
+				t.Fatalf("expected one rendered resource, got %d", len(res.RenderedResources))
+			}
+			var tr pipelinev1.TaskRun
+			if err := json.Unmarshal(res.RenderedResources[0], &tr); err != nil {
+				t.Fatalf("failed to decode rendered resource: %v", err)
+			}
+			if tr.Name != "push-run" {
+				t.Errorf("rendered TaskRun has name %q, want %q", tr.Name, "push-run")
+			}
+		})
+	}
+}
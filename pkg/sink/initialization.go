@@ -18,6 +18,8 @@ package sink
 
 import (
 	"flag"
+	"net"
+	"strings"
 	"time"
 
 	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
@@ -51,14 +53,40 @@ var (
 		"The idle timeout for EventListener Server.")
 	elTimeOutHandler = flag.Int64("timeouthandler", 5,
 		"The timeout for Timeout Handler of EventListener Server.")
+	elShutdownGracePeriod = flag.Int64("shutdown-grace-period", 25,
+		"The time in seconds the EventListener waits for triggers being processed to finish when it shuts down.")
 	isMultiNSFlag = flag.Bool("is-multi-ns", false,
 		"Whether EventListener serve Multiple NS.")
 	tlsCertFlag = flag.String("tls-cert", "",
 		"The filename for the TLS certificate.")
 	tlsKeyFlag = flag.String("tls-key", "",
 		"The filename for the TLS key.")
+	tlsClientCAFlag = flag.String("tls-client-ca", "",
+		"The filename for the CA certificates that client certificates are verified with. If empty, clients are not authenticated.")
+	tlsClientAuthFlag = flag.String("tls-client-auth", "Required",
+		"Whether clients must present a certificate, Required or Optional.")
 	payloadValidation = flag.Bool("payload-validation", true,
 		"Whether to disable payload validation or not.")
+	maxWorkers = flag.Int("max-workers", 100,
+		"The maximum number of triggers processed concurrently. Set to 0 to process every trigger in its own goroutine.")
+	maxQueueDepth = flag.Int("max-queue-depth", 10000,
+		"The maximum number of triggers waiting to be processed before events are rejected.")
+	maxBodySize = flag.Int64("max-body-size", 25*1024*1024,
+		"The maximum size in bytes of request bodies. Set to 0 to accept bodies of any size.")
+	adminPortFlag = flag.String("admin-port", "",
+		"The port for the liveness, readiness, pprof and metrics endpoints. If empty, liveness and readiness are served on the port of the EventListener.")
+	enablePprof = flag.Bool("enable-pprof", false,
+		"Whether to serve pprof endpoints on the admin port.")
+	allowedContentTypes = flag.String("allowed-content-types", "",
+		"A comma separated list of the media types that requests may send. If empty, any content type is accepted.")
+	allowedSourceRanges = flag.String("allowed-source-ranges", "",
+		"A comma separated list of the IP ranges, in CIDR notation, that requests may be sent from. If empty, requests may be sent from any IP.")
+	trustedProxies = flag.String("trusted-proxies", "",
+		"A comma separated list of the IP ranges, in CIDR notation, of the proxies whose X-Forwarded-For header is trusted.")
+	clientCacheTTL = flag.Int64("client-cache-ttl", 600,
+		"The time in seconds the clients that impersonate the service accounts of triggers are reused. Set to 0 to create a client for every event.")
+	tracingCollectorAddress = flag.String("tracing-collector-address", "",
+		"The host:port of the OpenTelemetry Collector that traces are exported to over OTLP/HTTP. If empty, traces are not exported.")
 )
 
 // Args define the arguments for Sink.
@@ -69,6 +97,10 @@ type Args struct {
 	ElNamespace string
 	// Port is the port the Sink should listen on.
 	Port string
+	// AdminPort is the port the admin endpoints of the Sink are served on.
+	AdminPort string
+	// EnablePprof defines whether pprof endpoints are served on the AdminPort
+	EnablePprof bool
 	// ELReadTimeOut defines the read timeout for EventListener Server
 	ELReadTimeOut time.Duration
 	// ELWriteTimeOut defines the write timeout for EventListener Server
@@ -77,14 +109,36 @@ type Args struct {
 	ELIdleTimeOut time.Duration
 	// ELTimeOutHandler defines the timeout for Timeout Handler of EventListener Server
 	ELTimeOutHandler time.Duration
+	// ShutdownGracePeriod defines how long triggers being processed are waited on at shutdown
+	ShutdownGracePeriod time.Duration
 	// IsMultiNS determines whether el functions as namespaced or clustered
 	IsMultiNS bool
 	// Key defines the filename for tls Key.
 	Key string
 	// Cert defines the filename for tls Cert.
 	Cert string
+	// ClientCA defines the filename for the CA certificates that client certificates are verified with.
+	ClientCA string
+	// ClientAuth defines whether clients must present a certificate.
+	ClientAuth string
 	// PayloadValidation defines whether to validate payload or not
 	PayloadValidation bool
+	// MaxWorkers defines the maximum number of triggers processed concurrently
+	MaxWorkers int
+	// MaxQueueDepth defines the maximum number of triggers waiting to be processed
+	MaxQueueDepth int
+	// MaxBodySize defines the maximum size in bytes of request bodies
+	MaxBodySize int64
+	// AllowedContentTypes defines the media types that requests may send
+	AllowedContentTypes []string
+	// AllowedSourceRanges defines the IP ranges that requests may be sent from
+	AllowedSourceRanges []*net.IPNet
+	// TrustedProxies defines the IP ranges of the proxies whose X-Forwarded-For header is trusted
+	TrustedProxies []*net.IPNet
+	// ClientCacheTTL defines how long the clients that impersonate service accounts are reused
+	ClientCacheTTL time.Duration
+	// TracingCollectorAddress defines the address of the collector traces are exported to
+	TracingCollectorAddress string
 }
 
 // Clients define the set of client dependencies Sink requires.
@@ -106,22 +160,67 @@ func GetArgs() (Args, error) {
 	if *portFlag == "" {
 		return Args{}, xerrors.Errorf("-%s arg not found", port)
 	}
+	sourceRanges, err := parseCIDRs(*allowedSourceRanges)
+	if err != nil {
+		return Args{}, xerrors.Errorf("invalid -allowed-source-ranges: %w", err)
+	}
+	proxies, err := parseCIDRs(*trustedProxies)
+	if err != nil {
+		return Args{}, xerrors.Errorf("invalid -trusted-proxies: %w", err)
+	}
 
 	return Args{
-		ElName:            *nameFlag,
-		ElNamespace:       *namespaceFlag,
-		Port:              *portFlag,
-		IsMultiNS:         *isMultiNSFlag,
-		PayloadValidation: *payloadValidation,
-		ELReadTimeOut:     time.Duration(*elReadTimeOut),
-		ELWriteTimeOut:    time.Duration(*elWriteTimeOut),
-		ELIdleTimeOut:     time.Duration(*elIdleTimeOut),
-		ELTimeOutHandler:  time.Duration(*elTimeOutHandler),
-		Cert:              *tlsCertFlag,
-		Key:               *tlsKeyFlag,
+		ElName:                  *nameFlag,
+		ElNamespace:             *namespaceFlag,
+		Port:                    *portFlag,
+		AdminPort:               *adminPortFlag,
+		EnablePprof:             *enablePprof,
+		IsMultiNS:               *isMultiNSFlag,
+		PayloadValidation:       *payloadValidation,
+		ELReadTimeOut:           time.Duration(*elReadTimeOut),
+		ELWriteTimeOut:          time.Duration(*elWriteTimeOut),
+		ELIdleTimeOut:           time.Duration(*elIdleTimeOut),
+		ELTimeOutHandler:        time.Duration(*elTimeOutHandler),
+		ShutdownGracePeriod:     time.Duration(*elShutdownGracePeriod),
+		Cert:                    *tlsCertFlag,
+		Key:                     *tlsKeyFlag,
+		ClientCA:                *tlsClientCAFlag,
+		ClientAuth:              *tlsClientAuthFlag,
+		MaxWorkers:              *maxWorkers,
+		MaxQueueDepth:           *maxQueueDepth,
+		MaxBodySize:             *maxBodySize,
+		AllowedContentTypes:     splitList(*allowedContentTypes),
+		AllowedSourceRanges:     sourceRanges,
+		TrustedProxies:          proxies,
+		ClientCacheTTL:          time.Duration(*clientCacheTTL),
+		TracingCollectorAddress: *tracingCollectorAddress,
 	}, nil
 }
 
+// splitList returns the items in the comma separated list s.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseCIDRs returns the IP ranges in the comma separated list s of CIDRs.
+func parseCIDRs(s string) ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+	for _, cidr := range splitList(s) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, ipNet)
+	}
+	return ranges, nil
+}
+
 // ConfigureClients returns the kubernetes and triggers clientsets
 func ConfigureClients(clusterConfig *rest.Config) (Clients, error) {
 	kubeClient, err := kubeclientset.NewForConfig(clusterConfig)
@@ -20,6 +20,8 @@ import (
 	"flag"
 	"strconv"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func Test_GetArgs(t *testing.T) {
@@ -35,6 +37,18 @@ func Test_GetArgs(t *testing.T) {
 	if err := flag.Set(isMultiNS, "true"); err != nil {
 		t.Errorf("Error setting flag isMultiNS: %s", err)
 	}
+	if err := flag.Set("allowed-content-types", "application/json, application/cloudevents+json,"); err != nil {
+		t.Errorf("Error setting flag allowed-content-types: %s", err)
+	}
+	if err := flag.Set("allowed-source-ranges", "192.30.252.0/22,2a0a:a440::/29"); err != nil {
+		t.Errorf("Error setting flag allowed-source-ranges: %s", err)
+	}
+	if err := flag.Set("tracing-collector-address", "otel-collector:4318"); err != nil {
+		t.Errorf("Error setting flag tracing-collector-address: %s", err)
+	}
+	if err := flag.Set("tls-client-ca", "/etc/triggers/client-ca/ca.crt"); err != nil {
+		t.Errorf("Error setting flag tls-client-ca: %s", err)
+	}
 
 	sinkArgs, err := GetArgs()
 	if err != nil {
@@ -55,6 +69,31 @@ func Test_GetArgs(t *testing.T) {
 	if sinkArgs.PayloadValidation != true {
 		t.Errorf("Error EL PayloadValidation want true, got %t", sinkArgs.PayloadValidation)
 	}
+	if sinkArgs.ShutdownGracePeriod != 25 {
+		t.Errorf("Error EL ShutdownGracePeriod want 25, got %d", sinkArgs.ShutdownGracePeriod)
+	}
+	if sinkArgs.MaxBodySize != 25*1024*1024 {
+		t.Errorf("Error EL MaxBodySize want 26214400, got %d", sinkArgs.MaxBodySize)
+	}
+	if diff := cmp.Diff([]string{"application/json", "application/cloudevents+json"}, sinkArgs.AllowedContentTypes); diff != "" {
+		t.Errorf("Error EL AllowedContentTypes (-want, +got): %s", diff)
+	}
+	var sourceRanges []string
+	for _, r := range sinkArgs.AllowedSourceRanges {
+		sourceRanges = append(sourceRanges, r.String())
+	}
+	if diff := cmp.Diff([]string{"192.30.252.0/22", "2a0a:a440::/29"}, sourceRanges); diff != "" {
+		t.Errorf("Error EL AllowedSourceRanges (-want, +got): %s", diff)
+	}
+	if len(sinkArgs.TrustedProxies) != 0 {
+		t.Errorf("Error EL TrustedProxies want none, got %v", sinkArgs.TrustedProxies)
+	}
+	if sinkArgs.TracingCollectorAddress != "otel-collector:4318" {
+		t.Errorf("Error EL TracingCollectorAddress want otel-collector:4318, got %s", sinkArgs.TracingCollectorAddress)
+	}
+	if sinkArgs.ClientCA != "/etc/triggers/client-ca/ca.crt" || sinkArgs.ClientAuth != "Required" {
+		t.Errorf("Error EL client auth want /etc/triggers/client-ca/ca.crt and Required, got %s and %s", sinkArgs.ClientCA, sinkArgs.ClientAuth)
+	}
 }
 
 func Test_GetArgs_error(t *testing.T) {
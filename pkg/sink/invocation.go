@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/kmeta"
+)
+
+// defaultTriggerInvocationTTL is how long TriggerInvocations are kept if the
+// EventListener does not set a TTL.
+const defaultTriggerInvocationTTL = 24 * time.Hour
+
+// createTriggerInvocationTimeout bounds the creation of a TriggerInvocation, so that a
+// slow API server does not hold up the processing of the event.
+const createTriggerInvocationTimeout = 10 * time.Second
+
+// invocation collects how an event is processed for a Trigger, or for the
+// interceptors of a TriggerGroup, so that it can be recorded as a TriggerInvocation.
+type invocation struct {
+	// triggerGroup is the name of the TriggerGroup the Trigger was selected by, if any.
+	triggerGroup string
+	startTime    metav1.Time
+	interceptors []triggersv1alpha1.InterceptorInvocation
+	params       []triggersv1.Param
+}
+
+// newInvocation returns an invocation that starts now.
+func newInvocation(triggerGroup string) *invocation {
+	return &invocation{
+		triggerGroup: triggerGroup,
+		startTime:    metav1.Now(),
+	}
+}
+
+// forTrigger returns the invocation of a Trigger selected by the TriggerGroup
+// that i is the invocation of. It starts with the results of the interceptors
+// of the TriggerGroup.
+func (i *invocation) forTrigger() *invocation {
+	return &invocation{
+		triggerGroup: i.triggerGroup,
+		startTime:    i.startTime,
+		interceptors: append([]triggersv1alpha1.InterceptorInvocation(nil), i.interceptors...),
+	}
+}
+
+// recordInterceptor records the result of an interceptor that was called at start.
+func (i *invocation) recordInterceptor(name string, start time.Time, resp *triggersv1.InterceptorResponse, err error) {
+	if i == nil {
+		return
+	}
+	ii := triggersv1alpha1.InterceptorInvocation{
+		Name:     name,
+		Duration: metav1.Duration{Duration: time.Since(start)},
+	}
+	switch {
+	case err != nil:
+		ii.ErrorMessage = err.Error()
+	case resp != nil && !resp.Continue:
+		ii.Status = &triggersv1alpha1.Status{Code: resp.Status.Code, Message: resp.Status.Message}
+	default:
+		ii.Continue = true
+	}
+	i.interceptors = append(i.interceptors, ii)
+}
+
+// interceptorName returns the name that identifies i in a TriggerInvocation.
+func interceptorName(i *triggersv1.TriggerInterceptor) string {
+	if i.Name != nil && *i.Name != "" {
+		return *i.Name
+	}
+	if i.Webhook != nil {
+		if ref := i.Webhook.ObjectRef; ref != nil {
+			if ref.Namespace != "" {
+				return fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+			}
+			return ref.Name
+		}
+		if i.Webhook.URL != nil {
+			// The user info and query of the URL can carry credentials.
+			u := *i.Webhook.URL
+			u.User = nil
+			u.RawQuery = ""
+			u.Fragment = ""
+			return u.String()
+		}
+	}
+	return i.GetName()
+}
+
+// recordTriggerInvocation creates a TriggerInvocation for the result of processing
+// the event, if the EventListener records TriggerInvocations.
+func (r Sink) recordTriggerInvocation(ev *eventProcessing, res TriggerResult, inv *invocation, log *zap.SugaredLogger) {
+	if ev.triggerInvocations == nil || inv == nil {
+		return
+	}
+	prefix := "group-" + res.TriggerGroup
+	if res.Name != "" {
+		prefix = fmt.Sprintf("%s-%s", res.Namespace, res.Name)
+	}
+	labels := map[string]string{
+		triggers.GroupName + triggers.EventListenerLabelKey: r.EventListenerName,
+		triggers.GroupName + triggers.EventIDLabelKey:       ev.eventID,
+	}
+	if res.Name != "" {
+		labels[triggers.GroupName+triggers.TriggerLabelKey] = res.Name
+	}
+	if res.TriggerGroup != "" {
+		labels[triggers.GroupName+triggers.TriggerGroupLabelKey] = res.TriggerGroup
+	}
+	startTime := inv.startTime
+	completionTime := metav1.Now()
+	ti := &triggersv1alpha1.TriggerInvocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kmeta.ChildName(prefix, "-"+ev.eventID),
+			Namespace: r.EventListenerNamespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: triggersv1.SchemeGroupVersion.String(),
+				Kind:       "EventListener",
+				Name:       r.EventListenerName,
+				UID:        types.UID(ev.eventListenerUID),
+			}},
+		},
+		Spec: triggersv1alpha1.TriggerInvocationSpec{
+			EventID:          ev.eventID,
+			EventListener:    r.EventListenerName,
+			Trigger:          res.Name,
+			TriggerNamespace: res.Namespace,
+			TriggerGroup:     res.TriggerGroup,
+		},
+		Status: triggersv1alpha1.TriggerInvocationStatus{
+			Interceptors:   inv.interceptors,
+			ErrorMessage:   res.ErrorMessage,
+			StartTime:      &startTime,
+			CompletionTime: &completionTime,
+		},
+	}
+	for _, p := range inv.params {
+		ti.Status.Params = append(ti.Status.Params, triggersv1alpha1.Param{Name: p.Name, Value: p.Value})
+	}
+	for _, cr := range res.Resources {
+		ti.Status.Resources = append(ti.Status.Resources, triggersv1alpha1.InvocationResource{
+			APIVersion: cr.APIVersion,
+			Kind:       cr.Kind,
+			Namespace:  cr.Namespace,
+			Name:       cr.Name,
+			UID:        cr.UID,
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), createTriggerInvocationTimeout)
+	defer cancel()
+	if _, err := r.TriggersClient.TriggersV1alpha1().TriggerInvocations(r.EventListenerNamespace).Create(ctx, ti, metav1.CreateOptions{}); err != nil {
+		log.Errorf("failed to create TriggerInvocation %s: %v", ti.Name, err)
+	}
+}
+
+// StartTriggerInvocationGC deletes the TriggerInvocations of the EventListener
+// whose TTL has passed every interval until ctx is done.
+func (r Sink) StartTriggerInvocationGC(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.collectTriggerInvocations(ctx, now)
+		}
+	}
+}
+
+func (r Sink) collectTriggerInvocations(ctx context.Context, now time.Time) {
+	el, err := r.EventListenerLister.EventListeners(r.EventListenerNamespace).Get(r.EventListenerName)
+	if err != nil {
+		r.Logger.Errorf("failed to get EventListener %s: %v", r.EventListenerName, err)
+		return
+	}
+	p := el.Spec.TriggerInvocations
+	if p == nil {
+		// EventListeners that do not record TriggerInvocations might not be allowed to list them.
+		return
+	}
+	ttl := defaultTriggerInvocationTTL
+	if p.TTL != nil {
+		ttl = p.TTL.Duration
+	}
+
+	invocations := r.TriggersClient.TriggersV1alpha1().TriggerInvocations(r.EventListenerNamespace)
+	list, err := invocations.List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", triggers.GroupName+triggers.EventListenerLabelKey, r.EventListenerName),
+	})
+	if err != nil {
+		r.Logger.Errorf("failed to list TriggerInvocations: %v", err)
+		return
+	}
+	for i := range list.Items {
+		ti := &list.Items[i]
+		completed := ti.CreationTimestamp.Time
+		if ti.Status.CompletionTime != nil {
+			completed = ti.Status.CompletionTime.Time
+		}
+		if now.Before(completed.Add(ttl)) {
+			continue
+		}
+		if err := invocations.Delete(ctx, ti.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			r.Logger.Errorf("failed to delete expired TriggerInvocation %s: %v", ti.Name, err)
+		}
+	}
+}
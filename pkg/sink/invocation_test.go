@@ -0,0 +1,294 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	faketriggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/triggers/test"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func TestHandleEvent_TriggerInvocations(t *testing.T) {
+	elName := "invocation-el"
+	ttSpec := &triggersv1beta1.TriggerTemplateSpec{
+		Params: []triggersv1beta1.ParamSpec{
+			{Name: "name"},
+			{Name: "url", Default: ptr.String("testurl")},
+			{Name: "revision", Default: ptr.String("testrevision")},
+			{Name: "app", Default: ptr.String("triggers")},
+			{Name: "type", Default: ptr.String("bar")},
+		},
+		ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+			RawExtension: trResourceTemplate(t),
+		}},
+	}
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				TriggerInvocations: &triggersv1beta1.TriggerInvocationPolicy{},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name: "create-trigger",
+					Interceptors: []*triggersv1beta1.EventInterceptor{{
+						Ref: triggersv1beta1.InterceptorRef{Name: "cel"},
+						Params: []triggersv1beta1.InterceptorParams{{
+							Name:  "filter",
+							Value: test.ToV1JSON(t, "body.action == 'push'"),
+						}},
+					}},
+					Bindings: []*triggersv1beta1.EventListenerBinding{
+						{Name: "name", Value: ptr.String("$(body.action)-run")},
+					},
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: ttSpec},
+				}, {
+					Name: "filtered-trigger",
+					Interceptors: []*triggersv1beta1.EventInterceptor{{
+						Name: ptr.String("only-pull-requests"),
+						Ref:  triggersv1beta1.InterceptorRef{Name: "cel"},
+						Params: []triggersv1beta1.InterceptorParams{{
+							Name:  "filter",
+							Value: test.ToV1JSON(t, "has(body.pull_request)"),
+						}},
+					}},
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: ttSpec},
+				}},
+			},
+		}},
+		ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{cel},
+	}
+
+	sink, _ := getSinkAssets(t, resources, elName, nil)
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{"action":"push"}`)))
+	if err != nil {
+		t.Fatalf("error sending request: %s", err)
+	}
+	defer resp.Body.Close()
+	sink.WGProcessTriggers.Wait()
+
+	list, err := sink.TriggersClient.TriggersV1alpha1().TriggerInvocations(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list TriggerInvocations: %v", err)
+	}
+	got := list.Items
+	sort.Slice(got, func(i, j int) bool { return got[i].Spec.Trigger < got[j].Spec.Trigger })
+	for _, ti := range got {
+		if ti.Status.StartTime == nil || ti.Status.CompletionTime == nil {
+			t.Errorf("TriggerInvocation %s does not record its timings", ti.Name)
+		}
+		if len(ti.OwnerReferences) != 1 || ti.OwnerReferences[0].UID != elUID {
+			t.Errorf("TriggerInvocation %s is not owned by the EventListener: %v", ti.Name, ti.OwnerReferences)
+		}
+	}
+
+	want := []triggersv1alpha1.TriggerInvocation{{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels: map[string]string{
+				"triggers.tekton.dev/eventlistener": elName,
+				"triggers.tekton.dev/trigger":       "create-trigger",
+			},
+		},
+		Spec: triggersv1alpha1.TriggerInvocationSpec{
+			EventListener:    elName,
+			Trigger:          "create-trigger",
+			TriggerNamespace: namespace,
+		},
+		Status: triggersv1alpha1.TriggerInvocationStatus{
+			Interceptors: []triggersv1alpha1.InterceptorInvocation{{
+				Name:     "cel",
+				Continue: true,
+			}},
+			Params: []triggersv1alpha1.Param{
+				{Name: "name", Value: "push-run"},
+				{Name: "url", Value: "testurl"},
+				{Name: "revision", Value: "testrevision"},
+				{Name: "app", Value: "triggers"},
+				{Name: "type", Value: "bar"},
+			},
+			Resources: []triggersv1alpha1.InvocationResource{{
+				APIVersion: "tekton.dev/v1beta1",
+				Kind:       "TaskRun",
+				Namespace:  namespace,
+				Name:       "push-run",
+			}},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels: map[string]string{
+				"triggers.tekton.dev/eventlistener": elName,
+				"triggers.tekton.dev/trigger":       "filtered-trigger",
+			},
+		},
+		Spec: triggersv1alpha1.TriggerInvocationSpec{
+			EventListener:    elName,
+			Trigger:          "filtered-trigger",
+			TriggerNamespace: namespace,
+		},
+		Status: triggersv1alpha1.TriggerInvocationStatus{
+			Interceptors: []triggersv1alpha1.InterceptorInvocation{{
+				Name: "only-pull-requests",
+				Status: &triggersv1alpha1.Status{
+					Code:    codes.FailedPrecondition,
+					Message: "expression has(body.pull_request) did not return true",
+				},
+			}},
+		},
+	}}
+	ignore := cmp.Options{
+		cmpopts.IgnoreFields(metav1.ObjectMeta{}, "Name", "OwnerReferences"),
+		cmpopts.IgnoreFields(triggersv1alpha1.TriggerInvocationSpec{}, "EventID"),
+		cmpopts.IgnoreFields(triggersv1alpha1.TriggerInvocationStatus{}, "StartTime", "CompletionTime"),
+		cmpopts.IgnoreFields(triggersv1alpha1.InterceptorInvocation{}, "Duration"),
+		cmpopts.IgnoreMapEntries(func(k, _ string) bool { return k == "triggers.tekton.dev/triggers-eventid" }),
+		cmpopts.SortSlices(func(a, b triggersv1alpha1.Param) bool { return a.Name < b.Name }),
+	}
+	if diff := cmp.Diff(want, got, ignore); diff != "" {
+		t.Errorf("did not get expected TriggerInvocations -want,+got: %s", diff)
+	}
+}
+
+func TestCollectTriggerInvocations(t *testing.T) {
+	now := time.Now()
+	invocation := func(name, el string, completed time.Time) *triggersv1alpha1.TriggerInvocation {
+		return &triggersv1alpha1.TriggerInvocation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"triggers.tekton.dev/eventlistener": el},
+			},
+			Status: triggersv1alpha1.TriggerInvocationStatus{
+				CompletionTime: &metav1.Time{Time: completed},
+			},
+		}
+	}
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gc-el",
+				Namespace: namespace,
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				TriggerInvocations: &triggersv1beta1.TriggerInvocationPolicy{
+					TTL: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}},
+	}
+	sink, _ := getSinkAssets(t, resources, "gc-el", nil)
+	invocations := sink.TriggersClient.TriggersV1alpha1().TriggerInvocations(namespace)
+	for _, ti := range []*triggersv1alpha1.TriggerInvocation{
+		invocation("expired", "gc-el", now.Add(-2*time.Hour)),
+		invocation("recent", "gc-el", now.Add(-time.Minute)),
+		invocation("other-el", "other-el", now.Add(-2*time.Hour)),
+	} {
+		if _, err := invocations.Create(context.Background(), ti, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sink.collectTriggerInvocations(context.Background(), now)
+
+	list, err := invocations.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ti := range list.Items {
+		got = append(got, ti.Name)
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff([]string{"other-el", "recent"}, got); diff != "" {
+		t.Errorf("did not keep expected TriggerInvocations -want,+got: %s", diff)
+	}
+}
+
+func TestCollectTriggerInvocations_Disabled(t *testing.T) {
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gc-el",
+				Namespace: namespace,
+			},
+		}},
+	}
+	sink, _ := getSinkAssets(t, resources, "gc-el", nil)
+	client := sink.TriggersClient.(*faketriggersclientset.Clientset)
+	client.ClearActions()
+
+	sink.collectTriggerInvocations(context.Background(), time.Now())
+
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("expected no requests for TriggerInvocations when they are not recorded, got %v", actions)
+	}
+}
+
+func TestInterceptorName(t *testing.T) {
+	tests := []struct {
+		name        string
+		interceptor *triggersv1beta1.TriggerInterceptor
+		want        string
+	}{{
+		name:        "named",
+		interceptor: &triggersv1beta1.TriggerInterceptor{Name: ptr.String("validate")},
+		want:        "validate",
+	}, {
+		name: "webhook service",
+		interceptor: &triggersv1beta1.TriggerInterceptor{Webhook: &triggersv1beta1.WebhookInterceptor{
+			ObjectRef: &corev1.ObjectReference{Kind: "Service", Namespace: "foo", Name: "my-interceptor"},
+		}},
+		want: "foo/my-interceptor",
+	}, {
+		name: "webhook URL with credentials",
+		interceptor: &triggersv1beta1.TriggerInterceptor{Webhook: &triggersv1beta1.WebhookInterceptor{
+			URL: &apis.URL{Scheme: "https", User: url.UserPassword("user", "secret"), Host: "example.com", Path: "/hook", RawQuery: "token=secret"},
+		}},
+		want: "https://example.com/hook",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := interceptorName(tc.interceptor); got != tc.want {
+				t.Errorf("interceptorName() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/client/clientset/versioned/scheme"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// The reasons of the Kubernetes Events emitted when processing a Trigger fails.
+const (
+	// InterceptorFailedReason is the reason of Events for interceptors that could not be called.
+	InterceptorFailedReason = "InterceptorFailed"
+	// TriggerResolutionFailedReason is the reason of Events for Triggers whose bindings
+	// or template could not be found.
+	TriggerResolutionFailedReason = "TriggerResolutionFailed"
+	// ParamsResolutionFailedReason is the reason of Events for Triggers whose params
+	// could not be resolved from the event.
+	ParamsResolutionFailedReason = "ParamsResolutionFailed"
+	// ResourceCreationFailedReason is the reason of Events for Triggers whose resources
+	// could not be created.
+	ResourceCreationFailedReason = "ResourceCreationFailed"
+)
+
+// eventComponent is the source component of the Kubernetes Events emitted by EventListeners.
+const eventComponent = "eventlistener"
+
+// NewEventRecorder returns a recorder that emits Kubernetes Events from the EventListener
+// elName with kubeClient, and a function that stops emitting them. Similar Events about
+// the same object are aggregated and rate limited by the recorder, so that a failing
+// Trigger does not flood the API server with Events.
+func NewEventRecorder(kubeClient kubernetes.Interface, elName string) (record.EventRecorder, func()) {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: eventComponent, Host: elName}), broadcaster.Shutdown
+}
+
+// recordTriggerFailure emits a Warning Event with reason about the failure to process
+// the Trigger t. Triggers that are inlined in the EventListener are not objects of their
+// own, so the Events about them are emitted on the EventListener.
+func (r Sink) recordTriggerFailure(ev *eventProcessing, t *triggersv1.Trigger, reason string, err error, log *zap.SugaredLogger) {
+	if r.EventRecorder == nil || ev.dryRun {
+		return
+	}
+	if t.UID == "" {
+		r.recordEventListenerFailure(ev, reason, log, "Trigger %s failed to process event %s: %v", t.Name, ev.eventID, err)
+		return
+	}
+	r.EventRecorder.Eventf(t, corev1.EventTypeWarning, reason, "Failed to process event %s: %v", ev.eventID, err)
+}
+
+// recordEventListenerFailure emits a Warning Event with reason on the EventListener.
+func (r Sink) recordEventListenerFailure(ev *eventProcessing, reason string, log *zap.SugaredLogger, messageFmt string, args ...interface{}) {
+	if r.EventRecorder == nil || ev.dryRun {
+		return
+	}
+	el, err := r.EventListenerLister.EventListeners(r.EventListenerNamespace).Get(r.EventListenerName)
+	if err != nil {
+		log.Warnf("Failed to get EventListener to emit %s Event: %v", reason, err)
+		return
+	}
+	r.EventRecorder.Eventf(el, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/ptr"
+)
+
+func TestHandleEvent_KubernetesEvents(t *testing.T) {
+	elName := "events-el"
+	el := func(triggers ...triggersv1beta1.EventListenerTrigger) *triggersv1beta1.EventListener {
+		return &triggersv1beta1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{Triggers: triggers},
+		}
+	}
+	template := func(t *testing.T) *triggersv1beta1.EventListenerTemplate {
+		return &triggersv1beta1.EventListenerTemplate{
+			Spec: &triggersv1beta1.TriggerTemplateSpec{
+				Params: []triggersv1beta1.ParamSpec{
+					{Name: "url", Default: ptr.String("testurl")},
+					{Name: "revision", Default: ptr.String("testrevision")},
+					{Name: "name", Default: ptr.String("git-clone-run")},
+					{Name: "app", Default: ptr.String("triggers")},
+					{Name: "type", Default: ptr.String("bar")},
+				},
+				ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+					RawExtension: trResourceTemplate(t),
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		resources func(t *testing.T) test.Resources
+		prepare   func(*fakedynamic.FakeDynamicClient)
+		want      string
+	}{{
+		name: "missing binding of a Trigger is reported on the Trigger",
+		resources: func(t *testing.T) test.Resources {
+			return test.Resources{
+				EventListeners: []*triggersv1beta1.EventListener{el(triggersv1beta1.EventListenerTrigger{TriggerRef: "my-trigger"})},
+				Triggers: []*triggersv1beta1.Trigger{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-trigger",
+						Namespace: namespace,
+						UID:       "trigger-uid",
+					},
+					Spec: triggersv1beta1.TriggerSpec{
+						Bindings: []*triggersv1beta1.TriggerSpecBinding{{Ref: "missing"}},
+						Template: *template(t),
+					},
+				}},
+			}
+		},
+		want: "Warning TriggerResolutionFailed Failed to process event 12345: ",
+	}, {
+		name: "missing template of an inline trigger is reported on the EventListener",
+		resources: func(t *testing.T) test.Resources {
+			return test.Resources{
+				EventListeners: []*triggersv1beta1.EventListener{el(triggersv1beta1.EventListenerTrigger{
+					Name:     "inline-trigger",
+					Template: &triggersv1beta1.EventListenerTemplate{Ref: ptr.String("missing")},
+				})},
+			}
+		},
+		want: "Warning TriggerResolutionFailed Trigger inline-trigger failed to process event 12345: ",
+	}, {
+		name: "forbidden create is reported",
+		resources: func(t *testing.T) test.Resources {
+			return test.Resources{
+				EventListeners: []*triggersv1beta1.EventListener{el(triggersv1beta1.EventListenerTrigger{
+					Name:     "inline-trigger",
+					Template: template(t),
+				})},
+			}
+		},
+		prepare: func(dc *fakedynamic.FakeDynamicClient) {
+			dc.PrependReactor("create", "taskruns", func(ktesting.Action) (bool, runtime.Object, error) {
+				return true, nil, kerrors.NewForbidden(schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}, "", errors.New("not allowed"))
+			})
+		},
+		want: "Warning ResourceCreationFailed Trigger inline-trigger failed to process event 12345: ",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, dynamicClient := getSinkAssets(t, tc.resources(t), elName, nil)
+			if tc.prepare != nil {
+				tc.prepare(dynamicClient)
+			}
+			recorder := record.NewFakeRecorder(10)
+			sink.EventRecorder = recorder
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			sink.HandleEvent(resp, req)
+			sink.WGProcessTriggers.Wait()
+
+			select {
+			case got := <-recorder.Events:
+				if !strings.HasPrefix(got, tc.want) {
+					t.Errorf("expected Event starting with %q, got %q", tc.want, got)
+				}
+			default:
+				t.Fatal("expected an Event to be emitted")
+			}
+			select {
+			case got := <-recorder.Events:
+				t.Errorf("unexpected Event %q", got)
+			default:
+			}
+		})
+	}
+}
+
+func TestRecordTriggerFailure_DryRun(t *testing.T) {
+	sink, _ := getSinkAssets(t, test.Resources{}, "events-el", nil)
+	recorder := record.NewFakeRecorder(10)
+	sink.EventRecorder = recorder
+	trigger := &triggersv1beta1.Trigger{ObjectMeta: metav1.ObjectMeta{Name: "my-trigger", Namespace: namespace, UID: "trigger-uid"}}
+	sink.recordTriggerFailure(&eventProcessing{eventID: eventID, dryRun: true}, trigger, InterceptorFailedReason, errors.New("failed"), sink.Logger)
+	select {
+	case got := <-recorder.Events:
+		t.Errorf("expected no Event for a dry run, got %q", got)
+	default:
+	}
+}
+
+func TestNewEventRecorder(t *testing.T) {
+	kubeClient := fakekube.NewSimpleClientset()
+	recorder, stop := NewEventRecorder(kubeClient, "my-el")
+	defer stop()
+
+	// Triggers from listers do not set their kind, which is looked up in the scheme.
+	trigger := &triggersv1beta1.Trigger{ObjectMeta: metav1.ObjectMeta{Name: "my-trigger", Namespace: namespace, UID: "trigger-uid"}}
+	recorder.Eventf(trigger, corev1.EventTypeWarning, InterceptorFailedReason, "Failed to process event %s", eventID)
+
+	// The fake clientset does not create Events in the namespace of the event, like the
+	// API server does, so the Event that the recorder tries to create is checked instead.
+	var got *corev1.Event
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		for _, action := range kubeClient.Actions() {
+			if create, ok := action.(ktesting.CreateAction); ok && action.GetResource().Resource == "events" {
+				got = create.GetObject().(*corev1.Event)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("expected an Event to be created: %v", err)
+	}
+	want := corev1.ObjectReference{
+		Kind:       "Trigger",
+		Namespace:  namespace,
+		Name:       "my-trigger",
+		UID:        "trigger-uid",
+		APIVersion: "triggers.tekton.dev/v1beta1",
+	}
+	if diff := cmp.Diff(want, got.InvolvedObject); diff != "" {
+		t.Errorf("unexpected involved object -want,+got: %s", diff)
+	}
+	if got.Reason != InterceptorFailedReason || got.Source.Component != "eventlistener" || got.Source.Host != "my-el" {
+		t.Errorf("unexpected Event: %+v", got)
+	}
+}
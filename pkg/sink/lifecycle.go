@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/tektoncd/triggers/pkg/template"
+	"go.uber.org/zap"
+)
+
+// The types of the CloudEvents sent to the CloudEventURI of an EventListener as it processes events.
+const (
+	// EventReceivedType is sent when the EventListener accepts an event for processing.
+	EventReceivedType = "dev.tekton.event.triggers.received.v1"
+	// TriggerMatchedType is sent when the interceptors of a Trigger accept an event.
+	TriggerMatchedType = "dev.tekton.event.triggers.matched.v1"
+	// InterceptorRejectedType is sent when an interceptor of a Trigger or TriggerGroup stops processing an event.
+	InterceptorRejectedType = "dev.tekton.event.triggers.rejected.v1"
+	// ResourcesCreatedType is sent when the resources of a Trigger are created.
+	ResourcesCreatedType = "dev.tekton.event.triggers.created.v1"
+	// ResourcesSkippedType is sent when no resources are created for a Trigger because
+	// runs of the concurrency group of the event are in progress.
+	ResourcesSkippedType = "dev.tekton.event.triggers.skipped.v1"
+	// CreationFailedType is sent when an event could not be processed for a Trigger or TriggerGroup.
+	CreationFailedType = "dev.tekton.event.triggers.failed.v1"
+	// ProcessingInterruptedType is sent when the EventListener shuts down before it
+	// finishes processing an event.
+	ProcessingInterruptedType = "dev.tekton.event.triggers.interrupted.v1"
+)
+
+// lifecycleEventTimeout bounds the time spent sending a single lifecycle CloudEvent.
+const lifecycleEventTimeout = 10 * time.Second
+
+// LifecycleEvent is the data of the CloudEvents sent as the EventListener processes an event.
+type LifecycleEvent struct {
+	EventListener    string `json:"eventListener"`
+	EventListenerUID string `json:"eventListenerUID"`
+	Namespace        string `json:"namespace"`
+	EventID          string `json:"eventID"`
+	// Trigger is the result of processing the event for a Trigger or TriggerGroup, if
+	// the CloudEvent is about one.
+	Trigger *TriggerResult `json:"trigger,omitempty"`
+}
+
+// emitLifecycleEvent sends a CloudEvent of the given type to the CloudEventURI of the
+// EventListener the event is processed by, if it has one. The CloudEvent is sent in the
+// background, and failing to send it does not affect the processing of the event.
+func (r Sink) emitLifecycleEvent(ev *eventProcessing, eventType string, result *TriggerResult, log *zap.SugaredLogger) {
+	if r.CloudEventClient == nil || ev.cloudEventURI == nil {
+		return
+	}
+	e, err := r.newLifecycleEvent(ev, eventType, result)
+	if err != nil {
+		log.Errorf("failed to encode %s CloudEvent: %v", eventType, err)
+		return
+	}
+
+	target := ev.cloudEventURI.String()
+	r.WGProcessTriggers.Add(1)
+	go func() {
+		defer r.WGProcessTriggers.Done()
+		ctx, cancel := context.WithTimeout(cloudevents.ContextWithTarget(context.Background(), target), lifecycleEventTimeout)
+		defer cancel()
+		if res := r.CloudEventClient.Send(ctx, e); !cloudevents.IsACK(res) {
+			log.Errorf("failed to send %s CloudEvent to %s: %v", eventType, target, res)
+		}
+	}()
+}
+
+// newLifecycleEvent returns the lifecycle CloudEvent of the given type for the event.
+func (r Sink) newLifecycleEvent(ev *eventProcessing, eventType string, result *TriggerResult) (cloudevents.Event, error) {
+	e := cloudevents.NewEvent()
+	e.SetID(template.UUID())
+	e.SetType(eventType)
+	e.SetSource(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners/%s", r.EventListenerNamespace, r.EventListenerName))
+	e.SetTime(time.Now())
+	if result != nil {
+		if result.Name != "" {
+			e.SetSubject(result.Name)
+		} else {
+			e.SetSubject(result.TriggerGroup)
+		}
+	}
+	data := LifecycleEvent{
+		EventListener:    r.EventListenerName,
+		EventListenerUID: ev.eventListenerUID,
+		Namespace:        r.EventListenerNamespace,
+		EventID:          ev.eventID,
+		Trigger:          result,
+	}
+	err := e.SetData(cloudevents.ApplicationJSON, data)
+	return e, err
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+// receivedLifecycleEvent is a lifecycle CloudEvent received by a lifecycleReceiver.
+type receivedLifecycleEvent struct {
+	Type string
+	Data LifecycleEvent
+}
+
+// lifecycleReceiver records the lifecycle CloudEvents it receives.
+type lifecycleReceiver struct {
+	t      *testing.T
+	mu     sync.Mutex
+	events []receivedLifecycleEvent
+}
+
+func (l *lifecycleReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+	if err != nil {
+		l.t.Errorf("received invalid CloudEvent: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var data LifecycleEvent
+	if err := e.DataAs(&data); err != nil {
+		l.t.Errorf("received CloudEvent with invalid data: %v", err)
+	}
+	if want := "/apis/triggers.tekton.dev/v1beta1/namespaces/foo/eventlisteners/lifecycle-el"; e.Source() != want {
+		l.t.Errorf("received CloudEvent with source %s, want %s", e.Source(), want)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, receivedLifecycleEvent{Type: e.Type(), Data: data})
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func TestHandleEvent_LifecycleEvents(t *testing.T) {
+	elName := "lifecycle-el"
+	receiver := &lifecycleReceiver{t: t}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+	sinkURI, err := apis.ParseURL(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ttSpec := &triggersv1beta1.TriggerTemplateSpec{
+		Params: []triggersv1beta1.ParamSpec{
+			{Name: "name", Default: ptr.String("git-clone-run")},
+			{Name: "url", Default: ptr.String("testurl")},
+			{Name: "revision", Default: ptr.String("testrevision")},
+			{Name: "app", Default: ptr.String("triggers")},
+			{Name: "type", Default: ptr.String("bar")},
+		},
+		ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+			RawExtension: trResourceTemplate(t),
+		}},
+	}
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				CloudEventURI: sinkURI,
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name:     "create-trigger",
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: ttSpec},
+				}, {
+					Name: "filtered-trigger",
+					Interceptors: []*triggersv1beta1.EventInterceptor{{
+						Ref: triggersv1beta1.InterceptorRef{Name: "cel"},
+						Params: []triggersv1beta1.InterceptorParams{{
+							Name:  "filter",
+							Value: test.ToV1JSON(t, "has(body.pull_request)"),
+						}},
+					}},
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: ttSpec},
+				}, {
+					Name:     "failing-trigger",
+					Template: &triggersv1beta1.EventListenerTemplate{Ref: ptr.String("missing-template")},
+				}},
+			},
+		}},
+		ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{cel},
+	}
+
+	sink, _ := getSinkAssets(t, resources, elName, nil)
+	sink.CloudEventClient, err = cloudevents.NewClientHTTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	el := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer el.Close()
+
+	resp, err := http.Post(el.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("error sending request: %s", err)
+	}
+	defer resp.Body.Close()
+	sink.WGProcessTriggers.Wait()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected response code 202 but got: %v", resp.Status)
+	}
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	var got []receivedLifecycleEvent
+	for _, e := range receiver.events {
+		if e.Data.EventListener != elName || e.Data.EventListenerUID != elUID || e.Data.Namespace != namespace || e.Data.EventID == "" {
+			t.Errorf("received lifecycle event for the wrong event: %+v", e.Data)
+		}
+		if e.Data.Trigger != nil {
+			// The error message depends on the lister implementation.
+			e.Data.Trigger.ErrorMessage = strings.Split(e.Data.Trigger.ErrorMessage, ":")[0]
+		}
+		e.Data = LifecycleEvent{Trigger: e.Data.Trigger}
+		got = append(got, e)
+	}
+	sort.Slice(got, func(i, j int) bool {
+		if got[i].Type != got[j].Type {
+			return got[i].Type < got[j].Type
+		}
+		return got[i].Data.Trigger.Name < got[j].Data.Trigger.Name
+	})
+
+	want := []receivedLifecycleEvent{{
+		Type: ResourcesCreatedType,
+		Data: LifecycleEvent{Trigger: &TriggerResult{
+			Name:      "create-trigger",
+			Namespace: namespace,
+			Continue:  true,
+			Resources: []CreatedResource{{
+				APIVersion: "tekton.dev/v1beta1",
+				Kind:       "TaskRun",
+				Namespace:  namespace,
+				Name:       "git-clone-run",
+			}},
+		}},
+	}, {
+		Type: CreationFailedType,
+		Data: LifecycleEvent{Trigger: &TriggerResult{
+			Name:         "failing-trigger",
+			Namespace:    namespace,
+			Continue:     true,
+			ErrorMessage: "error getting TriggerTemplate missing-template",
+		}},
+	}, {
+		Type: TriggerMatchedType,
+		Data: LifecycleEvent{Trigger: &TriggerResult{
+			Name:      "create-trigger",
+			Namespace: namespace,
+			Continue:  true,
+		}},
+	}, {
+		Type: TriggerMatchedType,
+		Data: LifecycleEvent{Trigger: &TriggerResult{
+			Name:      "failing-trigger",
+			Namespace: namespace,
+			Continue:  true,
+		}},
+	}, {
+		Type: EventReceivedType,
+	}, {
+		Type: InterceptorRejectedType,
+		Data: LifecycleEvent{Trigger: &TriggerResult{
+			Name:      "filtered-trigger",
+			Namespace: namespace,
+			Status: &triggersv1beta1.Status{
+				Code:    codes.FailedPrecondition,
+				Message: "expression has(body.pull_request) did not return true",
+			},
+		}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("did not receive expected lifecycle events -want,+got: %s", diff)
+	}
+}
@@ -3,6 +3,7 @@ package sink
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"google.golang.org/grpc/codes"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"knative.dev/pkg/metrics"
 )
@@ -20,20 +22,99 @@ var (
 		"The eventlistener HTTP request duration",
 		stats.UnitDimensionless)
 	elDistribution = view.Distribution(metrics.BucketsNBy10(0.001, 5)...)
+	lastValue      = view.LastValue()
 	eventCount     = stats.Float64("event_count",
 		"number of events received by sink",
 		stats.UnitDimensionless)
 	triggeredResources = stats.Int64("triggered_resources", "Count of the number of triggered eventlistener resources", stats.UnitDimensionless)
+	queueDepth         = stats.Int64("queue_depth",
+		"number of triggers waiting to be processed",
+		stats.UnitDimensionless)
+	workerUtilization = stats.Float64("worker_utilization",
+		"ratio of workers that are processing triggers",
+		stats.UnitDimensionless)
+	timeoutCount = stats.Int64("timeout_count",
+		"number of times processing an event, a trigger or an interceptor timed out",
+		stats.UnitDimensionless)
+	interceptorDuration = stats.Float64("interceptor_duration_seconds",
+		"The duration of interceptor calls",
+		stats.UnitDimensionless)
+	bindingFailureCount = stats.Int64("binding_failure_count",
+		"number of times the bindings of a trigger could not be resolved",
+		stats.UnitDimensionless)
+	resourceCreationCount = stats.Int64("resource_creation_count",
+		"number of resources that were created or failed to be created",
+		stats.UnitDimensionless)
+	certificateReloadCount = stats.Int64("tls_certificate_reload_count",
+		"number of times the TLS certificate was reloaded, or failed to be reloaded",
+		stats.UnitDimensionless)
+	certificateExpiry = stats.Float64("tls_certificate_expiry_seconds",
+		"The number of seconds until the TLS certificate expires",
+		stats.UnitDimensionless)
+	sourceIPRejectedCount = stats.Int64("source_ip_rejected_count",
+		"number of requests rejected because they were not sent from an allowed IP",
+		stats.UnitDimensionless)
+	clientCacheCount = stats.Int64("client_cache_count",
+		"number of lookups of the clients that impersonate the service accounts of triggers",
+		stats.UnitDimensionless)
+	clientCacheSize = stats.Int64("client_cache_size",
+		"number of clients that impersonate the service accounts of triggers that are cached",
+		stats.UnitDimensionless)
+	prunedResourceCount = stats.Int64("pruned_resource_count",
+		"number of resources created by triggers that were pruned, failed to be pruned, or would be pruned by a dry run",
+		stats.UnitDimensionless)
+)
+
+const (
+	// MetricsTriggerLevelKey is the key of the config-observability-triggers ConfigMap that
+	// sets the level at which trigger, interceptor and resource creation metrics are recorded.
+	MetricsTriggerLevelKey = "metrics.trigger.level"
+	// TriggerLevelEventListener records metrics for the EventListener as a whole.
+	TriggerLevelEventListener = "eventlistener"
+	// TriggerLevelTrigger tags metrics with the Trigger and TriggerGroup they are recorded for.
+	TriggerLevelTrigger = "trigger"
 )
 
+// MetricsConfig configures the metrics recorded by an EventListener.
+type MetricsConfig struct {
+	// TriggerLevel is the level at which trigger metrics are recorded. Tagging metrics with
+	// each Trigger increases the number of time series by the number of Triggers.
+	TriggerLevel string
+}
+
+// NewMetricsConfig returns the MetricsConfig in the data of the config-observability-triggers ConfigMap.
+func NewMetricsConfig(data map[string]string) (*MetricsConfig, error) {
+	cfg := &MetricsConfig{TriggerLevel: TriggerLevelEventListener}
+	if level, ok := data[MetricsTriggerLevelKey]; ok {
+		switch level {
+		case TriggerLevelEventListener, TriggerLevelTrigger:
+			cfg.TriggerLevel = level
+		default:
+			return nil, fmt.Errorf("invalid %s %q, must be %q or %q", MetricsTriggerLevelKey, level, TriggerLevelEventListener, TriggerLevelTrigger)
+		}
+	}
+	return cfg, nil
+}
+
 const (
-	failTag    = "failed"
-	successTag = "succeeded"
+	failTag      = "failed"
+	successTag   = "succeeded"
+	rejectedTag  = "rejected"
+	duplicateTag = "duplicate"
+
+	clientCacheHitTag  = "hit"
+	clientCacheMissTag = "miss"
 )
 
 // NewRecorder creates a new metrics recorder instance
 // to log the TaskRun related metrics
 func NewRecorder() (*Recorder, error) {
+	return NewRecorderWithConfig(&MetricsConfig{TriggerLevel: TriggerLevelEventListener})
+}
+
+// NewRecorderWithConfig creates a new metrics recorder instance that records metrics
+// at the level of cfg.
+func NewRecorderWithConfig(cfg *MetricsConfig) (*Recorder, error) {
 	r := &Recorder{
 		initialized: true,
 
@@ -51,6 +132,32 @@ func NewRecorder() (*Recorder, error) {
 		return nil, err
 	}
 	r.kind = kind
+	scope, err := tag.NewKey("scope")
+	if err != nil {
+		return nil, err
+	}
+	r.scope = scope
+	for _, k := range []struct {
+		key  *tag.Key
+		name string
+	}{
+		{&r.trigger, "trigger"},
+		{&r.triggerGroup, "triggergroup"},
+		{&r.interceptor, "interceptor"},
+		{&r.code, "code"},
+		{&r.gvk, "gvk"},
+		{&r.result, "result"},
+	} {
+		if *k.key, err = tag.NewKey(k.name); err != nil {
+			return nil, err
+		}
+	}
+	// Only tag metrics with the trigger they are recorded for at the trigger level, to
+	// control the cardinality of the metrics.
+	var triggerKeys []tag.Key
+	if cfg.TriggerLevel == TriggerLevelTrigger {
+		triggerKeys = []tag.Key{r.trigger, r.triggerGroup}
+	}
 
 	err = view.Register(
 		&view.View{
@@ -70,6 +177,73 @@ func NewRecorder() (*Recorder, error) {
 			Aggregation: view.Count(),
 			TagKeys:     []tag.Key{r.status},
 		},
+		&view.View{
+			Description: queueDepth.Description(),
+			Measure:     queueDepth,
+			Aggregation: lastValue,
+		},
+		&view.View{
+			Description: workerUtilization.Description(),
+			Measure:     workerUtilization,
+			Aggregation: lastValue,
+		},
+		&view.View{
+			Description: timeoutCount.Description(),
+			Measure:     timeoutCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.scope},
+		},
+		&view.View{
+			Description: interceptorDuration.Description(),
+			Measure:     interceptorDuration,
+			Aggregation: elDistribution,
+			TagKeys:     append([]tag.Key{r.interceptor, r.code}, triggerKeys...),
+		},
+		&view.View{
+			Description: bindingFailureCount.Description(),
+			Measure:     bindingFailureCount,
+			Aggregation: view.Count(),
+			TagKeys:     triggerKeys,
+		},
+		&view.View{
+			Description: resourceCreationCount.Description(),
+			Measure:     resourceCreationCount,
+			Aggregation: view.Count(),
+			TagKeys:     append([]tag.Key{r.gvk, r.status}, triggerKeys...),
+		},
+		&view.View{
+			Description: certificateReloadCount.Description(),
+			Measure:     certificateReloadCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.status},
+		},
+		&view.View{
+			Description: certificateExpiry.Description(),
+			Measure:     certificateExpiry,
+			Aggregation: lastValue,
+		},
+		&view.View{
+			Description: sourceIPRejectedCount.Description(),
+			Measure:     sourceIPRejectedCount,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Description: clientCacheCount.Description(),
+			Measure:     clientCacheCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.result},
+		},
+		&view.View{
+			Description: clientCacheSize.Description(),
+			Measure:     clientCacheSize,
+			Aggregation: lastValue,
+		},
+		&view.View{
+			Description: prunedResourceCount.Description(),
+			Measure:     prunedResourceCount,
+			Aggregation: view.Count(),
+			TagKeys:     append([]tag.Key{r.gvk, r.status}, triggerKeys...),
+		},
 	)
 	if err != nil {
 		log.Fatalf("unable to register eventlistener metrics: %s", err)
@@ -126,6 +300,88 @@ func (s *Sink) recordCountMetrics(status string) {
 	metrics.Record(ctx, eventCount.M(1))
 }
 
+func (s *Sink) recordTimeoutMetrics(scope string) {
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(s.Recorder.scope, scope),
+	)
+	if err != nil {
+		s.Logger.Warnf("failed to create tag for metric timeout_count: %w", err)
+		return
+	}
+
+	metrics.Record(ctx, timeoutCount.M(1))
+}
+
+// withTriggerTags returns ctx tagged with the trigger and trigger group that the metrics
+// recorded with it are recorded for.
+func (s *Sink) withTriggerTags(ctx context.Context, trigger, triggerGroup string) context.Context {
+	if s.Recorder == nil {
+		return ctx
+	}
+	tagged, err := tag.New(ctx,
+		tag.Upsert(s.Recorder.trigger, trigger),
+		tag.Upsert(s.Recorder.triggerGroup, triggerGroup),
+	)
+	if err != nil {
+		s.Logger.Warnf("failed to create tags for trigger %s: %v", trigger, err)
+		return ctx
+	}
+	return tagged
+}
+
+// recordInterceptorMetrics records the duration and outcome of a call to an interceptor,
+// for the trigger that ctx is tagged with.
+func (s *Sink) recordInterceptorMetrics(ctx context.Context, interceptor string, code codes.Code, elapsed time.Duration) {
+	if s.Recorder == nil {
+		return
+	}
+	ctx, err := tag.New(ctx,
+		tag.Insert(s.Recorder.interceptor, interceptor),
+		tag.Insert(s.Recorder.code, code.String()),
+	)
+	if err != nil {
+		s.Logger.Warnf("failed to create tags for metric interceptor_duration_seconds: %v", err)
+		return
+	}
+	metrics.Record(ctx, interceptorDuration.M(elapsed.Seconds()))
+}
+
+// recordBindingFailure records that the bindings of the trigger that ctx is tagged with
+// could not be resolved.
+func (s *Sink) recordBindingFailure(ctx context.Context) {
+	if s.Recorder == nil {
+		return
+	}
+	metrics.Record(ctx, bindingFailureCount.M(1))
+}
+
+// recordCreateMetrics records the outcome of creating the resource rt, for the trigger that
+// ctx is tagged with.
+func (s *Sink) recordCreateMetrics(ctx context.Context, rt json.RawMessage, createErr error) {
+	if s.Recorder == nil {
+		return
+	}
+	data := new(unstructured.Unstructured)
+	if err := data.UnmarshalJSON(rt); err != nil {
+		s.Logger.Warnf("couldn't unmarshal json from the TriggerTemplate: %v", err)
+		return
+	}
+	status := successTag
+	if createErr != nil {
+		status = failTag
+	}
+	ctx, err := tag.New(ctx,
+		tag.Insert(s.Recorder.gvk, data.GetAPIVersion()+"/"+data.GetKind()),
+		tag.Insert(s.Recorder.status, status),
+	)
+	if err != nil {
+		s.Logger.Warnf("failed to create tags for metric resource_creation_count: %v", err)
+		return
+	}
+	metrics.Record(ctx, resourceCreationCount.M(1))
+}
+
 func (s *Sink) recordResourceCreation(resources []json.RawMessage) {
 	for _, rt := range resources {
 		// Assume the TriggerResourceTemplate is valid (it has an apiVersion and Kind)
@@ -144,11 +400,79 @@ func (s *Sink) recordResourceCreation(resources []json.RawMessage) {
 	}
 }
 
+// recordCertificateReload records that the TLS certificate was reloaded, or failed to be.
+func (c *CertificateReloader) recordCertificateReload(status string) {
+	if c.recorder == nil {
+		return
+	}
+	ctx, err := tag.New(context.Background(), tag.Insert(c.recorder.status, status))
+	if err != nil {
+		c.logger.Warnf("failed to create tags for metric tls_certificate_reload_count: %v", err)
+		return
+	}
+	metrics.Record(ctx, certificateReloadCount.M(1))
+}
+
+// recordCertificateExpiry records the time left at now until the TLS certificate expires at notAfter.
+func (c *CertificateReloader) recordCertificateExpiry(notAfter, now time.Time) {
+	if c.recorder == nil {
+		return
+	}
+	metrics.Record(context.Background(), certificateExpiry.M(notAfter.Sub(now).Seconds()))
+}
+
+// recordClientCacheLookup records a lookup in the cache of impersonating clients with
+// result hit or miss, and the number of clients that are cached after it.
+func (c *clientCache) recordClientCacheLookup(result string, size int) {
+	if c.recorder == nil {
+		return
+	}
+	ctx, err := tag.New(context.Background(), tag.Insert(c.recorder.result, result))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, clientCacheCount.M(1))
+	metrics.Record(context.Background(), clientCacheSize.M(int64(size)))
+}
+
+// recordPruneMetrics records the outcome of pruning the resource obj, for the trigger that
+// ctx is tagged with.
+func (s *Sink) recordPruneMetrics(ctx context.Context, obj *unstructured.Unstructured, status string) {
+	if s.Recorder == nil {
+		return
+	}
+	ctx, err := tag.New(ctx,
+		tag.Insert(s.Recorder.gvk, obj.GetAPIVersion()+"/"+obj.GetKind()),
+		tag.Insert(s.Recorder.status, status),
+	)
+	if err != nil {
+		s.Logger.Warnf("failed to create tags for metric pruned_resource_count: %v", err)
+		return
+	}
+	metrics.Record(ctx, prunedResourceCount.M(1))
+}
+
+func recordSourceIPRejected() {
+	metrics.Record(context.Background(), sourceIPRejectedCount.M(1))
+}
+
+func recordPoolMetrics(queued int, busy int64, workers int) {
+	metrics.Record(context.Background(), queueDepth.M(int64(queued)))
+	metrics.Record(context.Background(), workerUtilization.M(float64(busy)/float64(workers)))
+}
+
 type Recorder struct {
 	initialized bool
 
-	status tag.Key
-	kind   tag.Key
+	status       tag.Key
+	kind         tag.Key
+	scope        tag.Key
+	trigger      tag.Key
+	triggerGroup tag.Key
+	interceptor  tag.Key
+	code         tag.Key
+	gvk          tag.Key
+	result       tag.Key
 
 	ReportingPeriod time.Duration
 }
@@ -3,12 +3,21 @@ package sink
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	"go.opencensus.io/stats/view"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"knative.dev/pkg/metrics"
 	"knative.dev/pkg/metrics/metricstest"
 )
@@ -169,3 +178,244 @@ func TestRecordRecordCountMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordTimeoutMetrics(t *testing.T) {
+	defer metricstest.Unregister("timeout_count", "event_count", "http_duration_seconds")
+	logger := zaptest.NewLogger(t).Sugar()
+	metrics.FlushExporter()
+	err := metrics.UpdateExporter(context.TODO(), metrics.ExporterOptions{
+		Domain:    "tekton.dev/triggers",
+		Component: "triggers",
+		ConfigMap: map[string]string{},
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := NewRecorder()
+	s := &Sink{
+		Recorder: r,
+		Logger:   logger,
+	}
+	s.recordTimeout(errors.New("not a timeout"))
+	s.recordTimeout(fmt.Errorf("wrapped: %w", &TimeoutError{Scope: interceptorTimeoutScope, Name: "cel", Timeout: time.Second}))
+	metricstest.CheckCountData(t, "timeout_count", map[string]string{"scope": interceptorTimeoutScope}, 1)
+}
+
+func TestNewMetricsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    *MetricsConfig
+		wantErr bool
+	}{{
+		name: "defaults to eventlistener level",
+		want: &MetricsConfig{TriggerLevel: TriggerLevelEventListener},
+	}, {
+		name: "trigger level",
+		data: map[string]string{MetricsTriggerLevelKey: TriggerLevelTrigger},
+		want: &MetricsConfig{TriggerLevel: TriggerLevelTrigger},
+	}, {
+		name:    "invalid level",
+		data:    map[string]string{MetricsTriggerLevelKey: "interceptor"},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewMetricsConfig(tc.data)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewMetricsConfig() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewMetricsConfig() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordTriggerMetrics(t *testing.T) {
+	pipelineRun := json.RawMessage(`{"apiVersion": "tekton.dev/v1beta1","kind": "PipelineRun","metadata": {"name": "simple-pipeline-run"}}`)
+	tests := []struct {
+		name        string
+		level       string
+		triggerTags map[string]string
+	}{{
+		name:        "eventlistener level",
+		level:       TriggerLevelEventListener,
+		triggerTags: map[string]string{},
+	}, {
+		name:  "trigger level",
+		level: TriggerLevelTrigger,
+		triggerTags: map[string]string{
+			"trigger":      "my-trigger",
+			"triggergroup": "my-group",
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer metricstest.Unregister("interceptor_duration_seconds", "binding_failure_count", "resource_creation_count",
+				"http_duration_seconds", "event_count", "triggered_resources", "queue_depth", "worker_utilization", "timeout_count",
+				"pruned_resource_count")
+			logger := zaptest.NewLogger(t).Sugar()
+			metrics.FlushExporter()
+			err := metrics.UpdateExporter(context.TODO(), metrics.ExporterOptions{
+				Domain:    "tekton.dev/triggers",
+				Component: "triggers",
+				ConfigMap: map[string]string{},
+			}, logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := NewRecorderWithConfig(&MetricsConfig{TriggerLevel: tc.level})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &Sink{
+				Recorder: r,
+				Logger:   logger,
+			}
+			ctx := s.withTriggerTags(context.Background(), "my-trigger", "my-group")
+			s.recordInterceptorMetrics(ctx, "cel", codes.FailedPrecondition, time.Second)
+			s.recordBindingFailure(ctx)
+			s.recordCreateMetrics(ctx, pipelineRun, nil)
+			s.recordCreateMetrics(ctx, pipelineRun, nil)
+			s.recordCreateMetrics(ctx, pipelineRun, errors.New("forbidden"))
+			s.recordPruneMetrics(ctx, &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun"}}, dryRunTag)
+
+			withTags := func(tags map[string]string) map[string]string {
+				for k, v := range tc.triggerTags {
+					tags[k] = v
+				}
+				return tags
+			}
+			metricstest.CheckDistributionData(t, "interceptor_duration_seconds",
+				withTags(map[string]string{"interceptor": "cel", "code": "FailedPrecondition"}), 1, 1, 1)
+			metricstest.CheckCountData(t, "binding_failure_count", withTags(map[string]string{}), 1)
+			metricstest.CheckCountData(t, "pruned_resource_count",
+				withTags(map[string]string{"gvk": "tekton.dev/v1beta1/PipelineRun", "status": dryRunTag}), 1)
+
+			rows, err := view.RetrieveData("resource_creation_count")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]int64{}
+			for _, row := range rows {
+				tags := map[string]string{}
+				for _, tag := range row.Tags {
+					tags[tag.Key.Name()] = tag.Value
+				}
+				if diff := cmp.Diff(withTags(map[string]string{"gvk": "tekton.dev/v1beta1/PipelineRun", "status": tags["status"]}), tags); diff != "" {
+					t.Errorf("resource_creation_count has unexpected tags -want,+got: %s", diff)
+				}
+				got[tags["status"]] = row.Data.(*view.CountData).Value
+			}
+			if diff := cmp.Diff(map[string]int64{successTag: 2, failTag: 1}, got); diff != "" {
+				t.Errorf("resource_creation_count -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestInterceptorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *triggersv1.InterceptorResponse
+		err  error
+		want codes.Code
+	}{{
+		name: "continued",
+		resp: &triggersv1.InterceptorResponse{Continue: true},
+		want: codes.OK,
+	}, {
+		name: "stopped",
+		resp: &triggersv1.InterceptorResponse{Status: triggersv1.Status{Code: codes.PermissionDenied}},
+		want: codes.PermissionDenied,
+	}, {
+		name: "timed out",
+		err:  &TimeoutError{Scope: interceptorTimeoutScope, Name: "cel", Timeout: time.Second},
+		want: codes.DeadlineExceeded,
+	}, {
+		name: "failed",
+		err:  errors.New("connection refused"),
+		want: codes.Unknown,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := interceptorCode(tc.resp, tc.err); got != tc.want {
+				t.Errorf("interceptorCode() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecordCertificateMetrics(t *testing.T) {
+	defer metricstest.Unregister("tls_certificate_reload_count", "tls_certificate_expiry_seconds",
+		"http_duration_seconds", "event_count", "triggered_resources", "queue_depth", "worker_utilization", "timeout_count",
+		"interceptor_duration_seconds", "binding_failure_count", "resource_creation_count", "pruned_resource_count")
+	logger := zaptest.NewLogger(t).Sugar()
+	metrics.FlushExporter()
+	err := metrics.UpdateExporter(context.TODO(), metrics.ExporterOptions{
+		Domain:    "tekton.dev/triggers",
+		Component: "triggers",
+		ConfigMap: map[string]string{},
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRecorder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &CertificateReloader{logger: logger, recorder: r}
+	now := time.Now()
+	c.recordCertificateReload(successTag)
+	c.recordCertificateReload(successTag)
+	c.recordCertificateExpiry(now.Add(time.Hour), now)
+
+	metricstest.CheckCountData(t, "tls_certificate_reload_count", map[string]string{"status": successTag}, 2)
+	metricstest.CheckLastValueData(t, "tls_certificate_expiry_seconds", map[string]string{}, time.Hour.Seconds())
+}
+
+func TestRecordClientCacheMetrics(t *testing.T) {
+	defer metricstest.Unregister("client_cache_count", "client_cache_size", "source_ip_rejected_count",
+		"tls_certificate_reload_count", "tls_certificate_expiry_seconds",
+		"http_duration_seconds", "event_count", "triggered_resources", "queue_depth", "worker_utilization", "timeout_count",
+		"interceptor_duration_seconds", "binding_failure_count", "resource_creation_count")
+	logger := zaptest.NewLogger(t).Sugar()
+	metrics.FlushExporter()
+	err := metrics.UpdateExporter(context.TODO(), metrics.ExporterOptions{
+		Domain:    "tekton.dev/triggers",
+		Component: "triggers",
+		ConfigMap: map[string]string{},
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRecorder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClientCache(time.Minute, r)
+	create := func() (dynamic.Interface, error) {
+		return fakedynamic.NewSimpleDynamicClient(runtime.NewScheme()), nil
+	}
+	for _, sa := range []string{"sa", "sa", "sa", "other-sa"} {
+		if _, err := c.get(sa, "ns", create); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows, err := view.RetrieveData("client_cache_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]int64{}
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			got[tag.Value] = row.Data.(*view.CountData).Value
+		}
+	}
+	if diff := cmp.Diff(map[string]int64{clientCacheHitTag: 2, clientCacheMissTag: 2}, got); diff != "" {
+		t.Errorf("unexpected client_cache_count -want,+got: %s", diff)
+	}
+	metricstest.CheckLastValueData(t, "client_cache_size", map[string]string{}, 2)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"path"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+)
+
+// matchesPath returns true if the URL path of a request matches the path of a
+// Trigger or TriggerGroup. An empty path matches every request.
+func matchesPath(p string, pathType triggersv1.PathType, requestPath string) bool {
+	if p == "" {
+		return true
+	}
+	p = path.Clean(p)
+	requestPath = path.Clean("/" + requestPath)
+	if pathType == triggersv1.PathTypePrefix {
+		return p == "/" || requestPath == p || strings.HasPrefix(requestPath, p+"/")
+	}
+	return requestPath == p
+}
+
+// triggersForPath returns the triggers whose path matches the URL path of a request.
+func triggersForPath(trs []*triggersv1.Trigger, requestPath string) []*triggersv1.Trigger {
+	var matched []*triggersv1.Trigger
+	for _, t := range trs {
+		if matchesPath(t.Spec.Path, t.Spec.PathType, requestPath) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// triggerGroupsForPath returns the trigger groups whose path matches the URL path of a request.
+func triggerGroupsForPath(groups []triggersv1.EventListenerTriggerGroup, requestPath string) []triggersv1.EventListenerTriggerGroup {
+	var matched []triggersv1.EventListenerTriggerGroup
+	for _, g := range groups {
+		if matchesPath(g.Path, g.PathType, requestPath) {
+			matched = append(matched, g)
+		}
+	}
+	return matched
+}
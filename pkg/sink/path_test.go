@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"testing"
+
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+)
+
+func TestMatchesPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		pathType    triggersv1beta1.PathType
+		requestPath string
+		want        bool
+	}{{
+		name:        "no path",
+		requestPath: "/anything",
+		want:        true,
+	}, {
+		name:        "exact match",
+		path:        "/github",
+		requestPath: "/github",
+		want:        true,
+	}, {
+		name:        "exact match with trailing slash",
+		path:        "/github",
+		requestPath: "/github/",
+		want:        true,
+	}, {
+		name:        "exact does not match sub path",
+		path:        "/github",
+		requestPath: "/github/push",
+		want:        false,
+	}, {
+		name:        "prefix matches sub path",
+		path:        "/github",
+		pathType:    triggersv1beta1.PathTypePrefix,
+		requestPath: "/github/push",
+		want:        true,
+	}, {
+		name:        "prefix matches path",
+		path:        "/github/",
+		pathType:    triggersv1beta1.PathTypePrefix,
+		requestPath: "/github",
+		want:        true,
+	}, {
+		name:        "prefix matches path elements only",
+		path:        "/github",
+		pathType:    triggersv1beta1.PathTypePrefix,
+		requestPath: "/githubapp",
+		want:        false,
+	}, {
+		name:        "root prefix matches everything",
+		path:        "/",
+		pathType:    triggersv1beta1.PathTypePrefix,
+		requestPath: "/gitlab",
+		want:        true,
+	}, {
+		name:        "empty request path",
+		path:        "/",
+		requestPath: "",
+		want:        true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := matchesPath(tc.path, tc.pathType, tc.requestPath); got != tc.want {
+				t.Errorf("matchesPath(%q, %q, %q) = %t, want %t", tc.path, tc.pathType, tc.requestPath, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrQueueFull is returned when the WorkerPool has no room left to queue work.
+var ErrQueueFull = errors.New("trigger processing queue is full")
+
+// WorkerPool processes triggers on a bounded number of goroutines. Work that
+// cannot be started right away waits in a queue with a bounded depth.
+type WorkerPool struct {
+	workers int
+	queue   chan func()
+	busy    int64
+
+	// mu guards sends on queue so that SubmitAll can queue all of its work or none of it.
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewWorkerPool returns a WorkerPool with the given number of workers and queue depth.
+// Start needs to be called before any queued work is processed.
+func NewWorkerPool(workers, queueDepth int) *WorkerPool {
+	return &WorkerPool{
+		workers: workers,
+		queue:   make(chan func(), queueDepth),
+	}
+}
+
+// Start starts the workers of the pool.
+func (p *WorkerPool) Start() {
+	for i := 0; i < p.workers; i++ {
+		go p.work()
+	}
+}
+
+func (p *WorkerPool) work() {
+	for fn := range p.queue {
+		busy := atomic.AddInt64(&p.busy, 1)
+		recordPoolMetrics(len(p.queue), busy, p.workers)
+		fn()
+		busy = atomic.AddInt64(&p.busy, -1)
+		recordPoolMetrics(len(p.queue), busy, p.workers)
+	}
+}
+
+// SubmitAll queues all of fns for processing. If the queue does not have room for all
+// of them, none of them are queued and ErrQueueFull is returned.
+func (p *WorkerPool) SubmitAll(fns ...func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || len(p.queue)+len(fns) > cap(p.queue) {
+		return ErrQueueFull
+	}
+	for _, fn := range fns {
+		p.queue <- fn
+	}
+	recordPoolMetrics(len(p.queue), atomic.LoadInt64(&p.busy), p.workers)
+	return nil
+}
+
+// SubmitOrRun queues fn for processing, or runs it on the calling goroutine if the
+// queue is full. It is used for work spawned by work that was already accepted,
+// which must neither be dropped nor block on the queue.
+func (p *WorkerPool) SubmitOrRun(fn func()) {
+	if err := p.SubmitAll(fn); err != nil {
+		fn()
+	}
+}
+
+// Close stops the pool from accepting more work. Work that was already queued is still
+// processed, and work submitted with SubmitOrRun runs on the calling goroutine.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+}
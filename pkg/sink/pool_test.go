@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestWorkerPool_SubmitAll(t *testing.T) {
+	p := NewWorkerPool(2, 3)
+	var wg sync.WaitGroup
+	done := func() { wg.Done() }
+
+	wg.Add(2)
+	if err := p.SubmitAll(done, done); err != nil {
+		t.Fatalf("SubmitAll() unexpected error: %v", err)
+	}
+	// Only one slot is left in the queue, so none of the work should be queued.
+	if err := p.SubmitAll(done, done); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SubmitAll() expected ErrQueueFull, got: %v", err)
+	}
+	if got := len(p.queue); got != 2 {
+		t.Fatalf("expected 2 queued functions, got %d", got)
+	}
+
+	p.Start()
+	wg.Wait()
+}
+
+func TestWorkerPool_SubmitOrRun(t *testing.T) {
+	p := NewWorkerPool(1, 0)
+	ran := false
+	// The queue has no room and the pool is not started, so fn must run on this goroutine.
+	p.SubmitOrRun(func() { ran = true })
+	if !ran {
+		t.Fatal("expected SubmitOrRun to run the function when the queue is full")
+	}
+}
+
+func TestWorkerPool_Close(t *testing.T) {
+	p := NewWorkerPool(1, 2)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if err := p.SubmitAll(wg.Done); err != nil {
+		t.Fatalf("SubmitAll() unexpected error: %v", err)
+	}
+	p.Close()
+	if err := p.SubmitAll(func() { t.Error("work submitted after Close should not run") }); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SubmitAll() after Close expected ErrQueueFull, got: %v", err)
+	}
+	// Work queued before Close is still processed.
+	p.Start()
+	wg.Wait()
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/resources"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const dryRunTag = "dryrun"
+
+// StartResourceRetention deletes the resources created by the Triggers of the
+// EventListener that their Retention no longer keeps every interval until ctx is done.
+func (r Sink) StartResourceRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.pruneResources(ctx, now)
+		}
+	}
+}
+
+func (r Sink) pruneResources(ctx context.Context, now time.Time) {
+	el, err := r.EventListenerLister.EventListeners(r.EventListenerNamespace).Get(r.EventListenerName)
+	if err != nil {
+		r.Logger.Errorf("failed to get EventListener %s: %v", r.EventListenerName, err)
+		return
+	}
+	ts, err := r.eventListenerTriggers(el)
+	if err != nil {
+		r.Logger.Errorf("failed to prune resources: %v", err)
+		return
+	}
+	for _, t := range ts {
+		retention := t.Spec.Retention
+		if retention == nil {
+			retention = el.Spec.Retention
+		}
+		if retention != nil {
+			r.pruneTriggerResources(ctx, t, retention, now)
+		}
+	}
+}
+
+// pruneTriggerResources deletes the resources created by the Trigger t that retention no
+// longer keeps at now. The resources are found by the kinds of the resource templates of
+// the Trigger that are created for each event, and by the labels of the EventListener, the
+// Trigger and its namespace. Resources that are written over with another CreatePolicy
+// are not created by an event, and are never pruned.
+func (r Sink) pruneTriggerResources(ctx context.Context, t *triggersv1.Trigger, retention *triggersv1.Retention, now time.Time) {
+	log := r.Logger.With(zap.String(triggers.TriggerLabelKey, t.Name))
+	ctx = r.withTriggerTags(ctx, t.Name, "")
+	kinds, err := r.templateKinds(t, createdByEvent)
+	if err != nil {
+		log.Errorf("failed to find the resources of the trigger to prune: %v", err)
+		return
+	}
+	dynamicClient, err := r.dynamicClientFor(t.Spec.ServiceAccountName, t.Namespace, log)
+	if err != nil {
+		log.Errorf("failed to prune resources of the trigger: %v", err)
+		return
+	}
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s,%s=%s,%s=%s,%s=true",
+		triggers.GroupName+triggers.EventListenerLabelKey, r.EventListenerName,
+		triggers.GroupName+triggers.TriggerLabelKey, t.Name,
+		triggers.GroupName+triggers.TriggerNamespaceLabelKey, t.Namespace,
+		triggers.GroupName+triggers.CreatedByEventLabelKey)}
+
+	for _, k := range kinds {
+		gvr, namespaced, err := resources.GroupVersionResource(k.apiVersion, k.kind, r.RESTMapper)
+		if err != nil {
+			log.Errorf("failed to prune resources: %v", err)
+			continue
+		}
+		var clients []dynamic.ResourceInterface
+		if namespaced {
+			for _, ns := range k.namespaces {
+				clients = append(clients, dynamicClient.Resource(gvr).Namespace(ns))
+			}
+		} else {
+			clients = append(clients, dynamicClient.Resource(gvr))
+		}
+		var objs []*unstructured.Unstructured
+		for _, client := range clients {
+			list, err := client.List(ctx, opts)
+			if err != nil {
+				log.Errorf("failed to list %s to prune: %v", gvr.Resource, err)
+				continue
+			}
+			for i := range list.Items {
+				objs = append(objs, &list.Items[i])
+			}
+		}
+
+		for _, obj := range expiredResources(objs, retention, now) {
+			if retention.DryRun {
+				log.Infof("dry run: would prune %s %s/%s created at %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), obj.GetCreationTimestamp().Format(time.RFC3339))
+				r.recordPruneMetrics(ctx, obj, dryRunTag)
+				continue
+			}
+			if err := resources.Delete(ctx, obj, r.RESTMapper, dynamicClient); err != nil {
+				log.Errorf("failed to prune %s %s/%s: %v", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+				r.recordPruneMetrics(ctx, obj, failTag)
+				continue
+			}
+			log.Infof("pruned %s %s/%s created at %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), obj.GetCreationTimestamp().Format(time.RFC3339))
+			r.recordPruneMetrics(ctx, obj, successTag)
+		}
+	}
+}
+
+// templateKind is a kind of the resources that a Trigger creates, and the namespaces it
+// creates them in.
+type templateKind struct {
+	apiVersion, kind string
+	namespaces       []string
+}
+
+// templateKinds returns the kinds of the resource templates of the Trigger t that include
+// returns true for. The kinds and namespaces that are set with params are not known, and
+// are left out.
+func (r Sink) templateKinds(t *triggersv1.Trigger, include func(json.RawMessage) bool) ([]templateKind, error) {
+	spec := t.Spec.Template.Spec
+	if spec == nil {
+		if t.Spec.Template.Ref == nil {
+			return nil, nil
+		}
+		tt, err := r.TriggerTemplateLister.TriggerTemplates(t.Namespace).Get(*t.Spec.Template.Ref)
+		if err != nil {
+			return nil, err
+		}
+		spec = &tt.Spec
+	}
+
+	var kinds []templateKind
+	index := map[string]int{}
+	for _, rt := range spec.ResourceTemplates {
+		if !include(rt.Raw) {
+			continue
+		}
+		var res templateResource
+		if err := json.Unmarshal(rt.Raw, &res); err != nil {
+			continue
+		}
+		ns := res.Metadata.Namespace
+		if ns == "" {
+			ns = t.Namespace
+		}
+		if isTemplated(res.APIVersion) || isTemplated(res.Kind) || isTemplated(ns) {
+			continue
+		}
+		key := res.APIVersion + "/" + res.Kind
+		i, ok := index[key]
+		if !ok {
+			i = len(kinds)
+			index[key] = i
+			kinds = append(kinds, templateKind{apiVersion: res.APIVersion, kind: res.Kind})
+		}
+		if !containsString(kinds[i].namespaces, ns) {
+			kinds[i].namespaces = append(kinds[i].namespaces, ns)
+		}
+	}
+	return kinds, nil
+}
+
+// expiredResources returns the resources of a kind in objs that retention no longer keeps
+// at now. Resources are expired when they are older than MaxAge, or are not among the
+// KeepLast most recent resources. Resources that are owned by other resources are deleted
+// with their owner, and runs that did not complete are kept.
+func expiredResources(objs []*unstructured.Unstructured, retention *triggersv1.Retention, now time.Time) []*unstructured.Unstructured {
+	var owners []*unstructured.Unstructured
+	for _, obj := range objs {
+		if len(obj.GetOwnerReferences()) == 0 {
+			owners = append(owners, obj)
+		}
+	}
+	sort.SliceStable(owners, func(i, j int) bool {
+		ti, tj := owners[i].GetCreationTimestamp(), owners[j].GetCreationTimestamp()
+		return tj.Before(&ti)
+	})
+	var expired []*unstructured.Unstructured
+	for i, obj := range owners {
+		tooMany := retention.KeepLast != nil && i >= int(*retention.KeepLast)
+		tooOld := retention.MaxAge != nil && now.Sub(obj.GetCreationTimestamp().Time) > retention.MaxAge.Duration
+		if (tooMany || tooOld) && isCompleted(obj) {
+			expired = append(expired, obj)
+		}
+	}
+	return expired
+}
+
+// isCompleted returns true if the resource obj completed. Runs complete when their
+// Succeeded condition is true or false. Other resources, such as ConfigMaps, are complete
+// unless they have a Succeeded condition that is unknown.
+func isCompleted(obj *unstructured.Unstructured) bool {
+	if obj.GroupVersionKind().Group == pipelinev1beta1.SchemeGroupVersion.Group && (obj.GetKind() == "PipelineRun" || obj.GetKind() == "TaskRun") {
+		return isDone(obj)
+	}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		c, ok := c.(map[string]interface{})
+		if ok && c["type"] == "Succeeded" {
+			return c["status"] != "Unknown"
+		}
+	}
+	return true
+}
+
+// isTemplated returns true if s is set with a param or variable.
+func isTemplated(s string) bool {
+	return strings.Contains(s, "$(")
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/ptr"
+)
+
+var retentionNow = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// createdRun returns a TaskRun created for an event by the Trigger of the EventListener
+// age before retentionNow. The run is done if succeeded is "True" or "False".
+func createdRun(name, el, trigger, succeeded string, age time.Duration) *unstructured.Unstructured {
+	u := run("TaskRun", name, "", succeeded, map[string]string{
+		triggers.GroupName + triggers.EventListenerLabelKey:    el,
+		triggers.GroupName + triggers.TriggerLabelKey:          trigger,
+		triggers.GroupName + triggers.TriggerNamespaceLabelKey: namespace,
+		triggers.GroupName + triggers.CreatedByEventLabelKey:   "true",
+	})
+	u.SetCreationTimestamp(metav1.NewTime(retentionNow.Add(-age)))
+	return u
+}
+
+func names(objs []*unstructured.Unstructured) []string {
+	var got []string
+	for _, obj := range objs {
+		got = append(got, obj.GetName())
+	}
+	return got
+}
+
+func TestExpiredResources(t *testing.T) {
+	owned := createdRun("owned", "el", "t", "True", 3*time.Hour)
+	owned.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun", Name: "owner"}})
+	objs := []*unstructured.Unstructured{
+		createdRun("old", "el", "t", "True", 2*time.Hour),
+		createdRun("new", "el", "t", "True", time.Minute),
+		createdRun("running", "el", "t", "Unknown", 3*time.Hour),
+		createdRun("failed", "el", "t", "False", time.Hour),
+		owned,
+	}
+
+	tests := []struct {
+		name      string
+		retention triggersv1beta1.Retention
+		want      []string
+	}{{
+		name:      "keep last",
+		retention: triggersv1beta1.Retention{KeepLast: ptr.Int32(2)},
+		want:      []string{"old"},
+	}, {
+		name:      "keep none",
+		retention: triggersv1beta1.Retention{KeepLast: ptr.Int32(0)},
+		want:      []string{"new", "failed", "old"},
+	}, {
+		name:      "max age",
+		retention: triggersv1beta1.Retention{MaxAge: &metav1.Duration{Duration: 30 * time.Minute}},
+		want:      []string{"failed", "old"},
+	}, {
+		name:      "keep last or max age",
+		retention: triggersv1beta1.Retention{KeepLast: ptr.Int32(3), MaxAge: &metav1.Duration{Duration: 90 * time.Minute}},
+		want:      []string{"old"},
+	}, {
+		name:      "nothing expired",
+		retention: triggersv1beta1.Retention{KeepLast: ptr.Int32(10), MaxAge: &metav1.Duration{Duration: 24 * time.Hour}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := names(expiredResources(objs, &tc.retention, retentionNow))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("expiredResources() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPruneResources(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		dryRun      bool
+		wantDeleted []string
+	}{{
+		name:        "prune",
+		wantDeleted: []string{"old"},
+	}, {
+		name:   "dry run",
+		dryRun: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			el := &triggersv1beta1.EventListener{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "retention-el",
+					Namespace: namespace,
+					UID:       types.UID(elUID),
+				},
+				Spec: triggersv1beta1.EventListenerSpec{
+					Triggers: []triggersv1beta1.EventListenerTrigger{{
+						Name: "retention",
+						Template: &triggersv1beta1.EventListenerTemplate{
+							Spec: &triggersv1beta1.TriggerTemplateSpec{
+								ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+									RawExtension: trResourceTemplate(t),
+								}},
+							},
+						},
+						Retention: &triggersv1beta1.Retention{KeepLast: ptr.Int32(1), DryRun: tc.dryRun},
+					}},
+				},
+			}
+			// other-namespace is created by a Trigger with the same name in another namespace.
+			otherNamespace := createdRun("other-namespace", el.Name, "retention", "True", 3*time.Hour)
+			labels := otherNamespace.GetLabels()
+			labels[triggers.GroupName+triggers.TriggerNamespaceLabelKey] = "bar"
+			otherNamespace.SetLabels(labels)
+			// written-over is written over with another CreatePolicy, rather than created for an event.
+			writtenOver := createdRun("written-over", el.Name, "retention", "True", 3*time.Hour)
+			labels = writtenOver.GetLabels()
+			delete(labels, triggers.GroupName+triggers.CreatedByEventLabelKey)
+			writtenOver.SetLabels(labels)
+			objs := []runtime.Object{
+				createdRun("new", el.Name, "retention", "True", time.Minute),
+				createdRun("old", el.Name, "retention", "True", 2*time.Hour),
+				createdRun("running", el.Name, "retention", "Unknown", 3*time.Hour),
+				createdRun("other-trigger", el.Name, "other", "True", 3*time.Hour),
+				createdRun("other-el", "other-el", "retention", "True", 3*time.Hour),
+				otherNamespace,
+				writtenOver,
+			}
+			s, _ := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1beta1.EventListener{el}}, el.Name, nil)
+			dc := newRunsClient(objs...)
+			s.DynamicClient = dc
+
+			s.pruneResources(context.Background(), retentionNow)
+
+			var deleted []string
+			for _, obj := range objs {
+				name := obj.(*unstructured.Unstructured).GetName()
+				_, err := dc.Resource(taskRunsGVR).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+				switch {
+				case errors.IsNotFound(err):
+					deleted = append(deleted, name)
+				case err != nil:
+					t.Fatal(err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantDeleted, deleted); diff != "" {
+				t.Errorf("pruned resources -want,+got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// defaultRetryBackoff is the delay before the first retry if a RetryPolicy does not set one.
+const defaultRetryBackoff = time.Second
+
+// deadLetterTimeout bounds each request to a DeadLetterSink.
+const deadLetterTimeout = 10 * time.Second
+
+// DeadLetter is the record sent to the DeadLetterSink of a RetryPolicy when an
+// event could not be processed for a Trigger or TriggerGroup.
+type DeadLetter struct {
+	EventListener string `json:"eventListener"`
+	Namespace     string `json:"namespace"`
+	EventID       string `json:"eventID"`
+	// Trigger is the namespace/name of the Trigger that failed, if any.
+	Trigger string `json:"trigger,omitempty"`
+	// TriggerGroup is the name of the TriggerGroup that failed or the Trigger was selected by, if any.
+	TriggerGroup string `json:"triggerGroup,omitempty"`
+	ErrorMessage string `json:"errorMessage"`
+	// Header and Body are the header and body of the incoming event.
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// Resources are the resolved resources that were not created.
+	Resources []json.RawMessage `json:"resources,omitempty"`
+}
+
+// retryBackoff returns the backoff to retry failed requests with. If p is nil
+// or does not allow any retries, requests are only attempted once.
+func retryBackoff(p *triggersv1.RetryPolicy) wait.Backoff {
+	if p == nil || p.Attempts <= 0 {
+		return wait.Backoff{Steps: 1}
+	}
+	d := defaultRetryBackoff
+	if p.Backoff != nil {
+		d = p.Backoff.Duration
+	}
+	return wait.Backoff{
+		Steps:    p.Attempts + 1,
+		Duration: d,
+		Factor:   2,
+		Jitter:   0.1,
+	}
+}
+
+// isRetryableCreateError returns true if creating a resource failed for a reason that
+// might not happen again: the API server was throttled, unavailable, timed out, failed
+// internally, or the resource conflicted with a concurrent change. A resource with a name
+// that already exists is not retried, as creating it again fails the same way.
+func isRetryableCreateError(err error) bool {
+	if kerrors.IsAlreadyExists(err) {
+		return false
+	}
+	return kerrors.IsTooManyRequests(err) ||
+		kerrors.IsServiceUnavailable(err) ||
+		kerrors.IsConflict(err) ||
+		kerrors.IsServerTimeout(err) ||
+		kerrors.IsTimeout(err) ||
+		kerrors.IsInternalError(err)
+}
+
+// isRetryableInterceptorError returns true if a ClusterInterceptor could not be
+// reached, did not respond in time, or responded that it is throttled or unavailable.
+func isRetryableInterceptorError(err error) bool {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr.Scope == interceptorTimeoutScope
+	}
+	var respErr *interceptors.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// newDeadLetter returns a DeadLetter for the event that failed to be processed with err.
+func (r Sink) newDeadLetter(eventID string, request *http.Request, event []byte, err error) DeadLetter {
+	return DeadLetter{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		EventID:       eventID,
+		ErrorMessage:  err.Error(),
+		Header:        deadLetterHeader(request.Header),
+		Body:          string(event),
+	}
+}
+
+// deadLetterHeader returns the header of an event without the headers that carry
+// credentials, such as the secrets and signatures of webhooks, which must not be
+// passed on to a DeadLetterSink.
+func deadLetterHeader(h http.Header) http.Header {
+	out := http.Header{}
+	for k, v := range h {
+		ck := http.CanonicalHeaderKey(k)
+		switch {
+		case ck == "Authorization", ck == "Proxy-Authorization", ck == "Cookie",
+			strings.HasSuffix(ck, "-Token"), strings.Contains(ck, "-Signature"):
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// DeadLetterSender bounds the number of dead letters that are sent at once. Dead letters
+// are sent in the background, so that DeadLetterSinks that are slow or unreachable do not
+// hold up the processing of other events.
+type DeadLetterSender struct {
+	slots chan struct{}
+}
+
+// NewDeadLetterSender returns a DeadLetterSender that sends up to maxInFlight dead
+// letters at once.
+func NewDeadLetterSender(maxInFlight int) *DeadLetterSender {
+	return &DeadLetterSender{slots: make(chan struct{}, maxInFlight)}
+}
+
+// acquire returns true if there is room to send another dead letter. A nil
+// DeadLetterSender always has room.
+func (s *DeadLetterSender) acquire() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *DeadLetterSender) release() {
+	if s != nil {
+		<-s.slots
+	}
+}
+
+// deadLetterResponseError is returned when a DeadLetterSink does not accept a dead letter.
+type deadLetterResponseError struct {
+	statusCode int
+}
+
+func (e *deadLetterResponseError) Error() string {
+	return fmt.Sprintf("dead letter sink responded with status %d", e.statusCode)
+}
+
+// isRetryableDeadLetterError returns true if a DeadLetterSink could not be reached, did
+// not respond in time, or responded that it is throttled or unavailable.
+func isRetryableDeadLetterError(err error) bool {
+	var respErr *deadLetterResponseError
+	if errors.As(err, &respErr) {
+		return respErr.statusCode == http.StatusTooManyRequests || respErr.statusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// sendDeadLetter sends dl to the DeadLetterSink of p, if it has one. The dead letter is
+// sent in the background, and is dropped if the DeadLetterSender has no room for it.
+func (r Sink) sendDeadLetter(p *triggersv1.RetryPolicy, dl DeadLetter, log *zap.SugaredLogger) {
+	if p == nil || p.DeadLetterSink == nil {
+		return
+	}
+	b, err := json.Marshal(dl)
+	if err != nil {
+		log.Errorf("failed to marshal dead letter: %v", err)
+		return
+	}
+	if !r.DeadLetterSender.acquire() {
+		log.Errorf("dropped dead letter for event %s: too many dead letters are being sent", dl.EventID)
+		return
+	}
+	r.WGProcessTriggers.Add(1)
+	go func() {
+		defer r.WGProcessTriggers.Done()
+		defer r.DeadLetterSender.release()
+		if err := r.postDeadLetter(p, b); err != nil {
+			log.Errorf("failed to send event to dead letter sink %s: %v", p.DeadLetterSink, err)
+			return
+		}
+		log.Infof("sent event to dead letter sink %s", p.DeadLetterSink)
+	}()
+}
+
+// postDeadLetter posts the encoded dead letter b to the DeadLetterSink of p, and retries
+// the requests that might succeed later as p allows.
+func (r Sink) postDeadLetter(p *triggersv1.RetryPolicy, b []byte) error {
+	return retry.OnError(retryBackoff(p), isRetryableDeadLetterError, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.DeadLetterSink.String(), bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		res, err := r.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return &deadLetterResponseError{statusCode: res.StatusCode}
+		}
+		return nil
+	})
+}
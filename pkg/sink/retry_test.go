@@ -0,0 +1,455 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/test"
+	"go.uber.org/zap/zaptest"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *triggersv1beta1.RetryPolicy
+		want   wait.Backoff
+	}{{
+		name: "no policy",
+		want: wait.Backoff{Steps: 1},
+	}, {
+		name:   "no attempts",
+		policy: &triggersv1beta1.RetryPolicy{Backoff: &metav1.Duration{Duration: time.Minute}},
+		want:   wait.Backoff{Steps: 1},
+	}, {
+		name:   "default backoff",
+		policy: &triggersv1beta1.RetryPolicy{Attempts: 3},
+		want:   wait.Backoff{Steps: 4, Duration: time.Second, Factor: 2, Jitter: 0.1},
+	}, {
+		name:   "backoff",
+		policy: &triggersv1beta1.RetryPolicy{Attempts: 2, Backoff: &metav1.Duration{Duration: 5 * time.Second}},
+		want:   wait.Backoff{Steps: 3, Duration: 5 * time.Second, Factor: 2, Jitter: 0.1},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, retryBackoff(tc.policy)); diff != "" {
+				t.Errorf("retryBackoff() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsRetryableCreateError(t *testing.T) {
+	gr := schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{{
+		name: "conflict",
+		err:  kerrors.NewConflict(gr, "tr", errors.New("conflict")),
+		want: true,
+	}, {
+		name: "server timeout",
+		err:  kerrors.NewServerTimeout(gr, "create", 1),
+		want: true,
+	}, {
+		name: "timeout",
+		err:  kerrors.NewTimeoutError("timed out", 1),
+		want: true,
+	}, {
+		name: "internal error",
+		err:  kerrors.NewInternalError(errors.New("etcd failed")),
+		want: true,
+	}, {
+		name: "too many requests",
+		err:  kerrors.NewTooManyRequests("slow down", 1),
+		want: true,
+	}, {
+		name: "wrapped service unavailable",
+		err:  fmt.Errorf("couldn't create resource: %w", kerrors.NewServiceUnavailable("unavailable")),
+		want: true,
+	}, {
+		name: "forbidden",
+		err:  kerrors.NewForbidden(gr, "tr", errors.New("forbidden")),
+		want: false,
+	}, {
+		name: "already exists",
+		err:  kerrors.NewAlreadyExists(gr, "tr"),
+		want: false,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableCreateError(tc.err); got != tc.want {
+				t.Errorf("isRetryableCreateError() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryableInterceptorError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{{
+		name: "server error",
+		err:  &interceptors.ResponseError{StatusCode: http.StatusBadGateway},
+		want: true,
+	}, {
+		name: "too many requests",
+		err:  &interceptors.ResponseError{StatusCode: http.StatusTooManyRequests},
+		want: true,
+	}, {
+		name: "bad request",
+		err:  &interceptors.ResponseError{StatusCode: http.StatusBadRequest},
+		want: false,
+	}, {
+		name: "network error",
+		err:  &url.Error{Op: "Post", URL: "http://interceptor", Err: errors.New("connection refused")},
+		want: true,
+	}, {
+		name: "other error",
+		err:  errors.New("invalid character"),
+		want: false,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableInterceptorError(tc.err); got != tc.want {
+				t.Errorf("isRetryableInterceptorError() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreateResources_Retry(t *testing.T) {
+	tr := test.RawExtension(t, pipelinev1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "TaskRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-taskrun",
+			Namespace: namespace,
+		},
+	})
+	policy := &triggersv1beta1.RetryPolicy{
+		Attempts: 2,
+		Backoff:  &metav1.Duration{Duration: time.Millisecond},
+	}
+	gr := schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}
+
+	tests := []struct {
+		name        string
+		failures    int
+		err         error
+		wantCreated int
+		wantErr     bool
+	}{{
+		name:        "succeeds after retries",
+		failures:    2,
+		err:         kerrors.NewServiceUnavailable("unavailable"),
+		wantCreated: 1,
+	}, {
+		name:     "retries exhausted",
+		failures: 3,
+		err:      kerrors.NewServiceUnavailable("unavailable"),
+		wantErr:  true,
+	}, {
+		name:        "conflict",
+		failures:    1,
+		err:         kerrors.NewConflict(gr, "my-taskrun", errors.New("conflict")),
+		wantCreated: 1,
+	}, {
+		name:        "server timeout",
+		failures:    1,
+		err:         kerrors.NewServerTimeout(gr, "create", 1),
+		wantCreated: 1,
+	}, {
+		name:        "timeout",
+		failures:    1,
+		err:         kerrors.NewTimeoutError("timed out", 1),
+		wantCreated: 1,
+	}, {
+		name:        "internal error",
+		failures:    1,
+		err:         kerrors.NewInternalError(errors.New("etcd failed")),
+		wantCreated: 1,
+	}, {
+		name:     "already exists",
+		failures: 1,
+		err:      kerrors.NewAlreadyExists(gr, "my-taskrun"),
+		wantErr:  true,
+	}, {
+		name:     "not retryable",
+		failures: 1,
+		err:      kerrors.NewBadRequest("bad request"),
+		wantErr:  true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := getSinkAssets(t, test.Resources{}, "my-el", nil)
+			calls := 0
+			dynamicClient.PrependReactor("create", "taskruns", func(ktesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= tc.failures {
+					return true, nil, tc.err
+				}
+				return false, nil, nil
+			})
+
+			created, err := s.CreateResources(context.Background(), namespace, "", []json.RawMessage{tr.Raw}, "my-trigger", eventID, s.Logger, policy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CreateResources() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if len(created) != tc.wantCreated {
+				t.Errorf("CreateResources() created %d resources, want %d", len(created), tc.wantCreated)
+			}
+		})
+	}
+}
+
+// flakyInterceptor is a HTTP server that responds with an error until it has
+// been called failures times, and then lets the event through.
+type flakyInterceptor struct {
+	failures int
+	calls    int
+}
+
+func (f *flakyInterceptor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls++
+	if f.calls <= f.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(triggersv1beta1.InterceptorResponse{Continue: true}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func TestExecuteInterceptors_Retry(t *testing.T) {
+	flaky := &triggersv1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "flaky",
+		},
+		Spec: triggersv1alpha1.ClusterInterceptorSpec{
+			ClientConfig: triggersv1alpha1.ClientConfig{
+				URL: &apis.URL{
+					Scheme: "http",
+					Host:   "flaky",
+					Path:   "/",
+				},
+			},
+		},
+	}
+	trInt := []*triggersv1beta1.TriggerInterceptor{{
+		Ref: triggersv1beta1.InterceptorRef{Name: "flaky"},
+	}}
+	policy := &triggersv1beta1.RetryPolicy{
+		Attempts: 2,
+		Backoff:  &metav1.Duration{Duration: time.Millisecond},
+	}
+	u, _ := url.Parse("http://example.com")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		interceptor := &flakyInterceptor{failures: 2}
+		s, _ := getSinkAssets(t, test.Resources{ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{flaky}}, "my-el", interceptor)
+		_, _, resp, err := s.ExecuteInterceptors(trInt, &http.Request{URL: u}, []byte(`{}`), s.Logger, eventID, "trigger", namespace, map[string]interface{}{}, policy)
+		if err != nil {
+			t.Fatalf("ExecuteInterceptors() unexpected error: %v", err)
+		}
+		if resp == nil || !resp.Continue {
+			t.Errorf("ExecuteInterceptors() expected response to continue, got %+v", resp)
+		}
+		if interceptor.calls != 3 {
+			t.Errorf("expected interceptor to be called 3 times, got %d", interceptor.calls)
+		}
+	})
+
+	t.Run("no retry policy", func(t *testing.T) {
+		interceptor := &flakyInterceptor{failures: 1}
+		s, _ := getSinkAssets(t, test.Resources{ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{flaky}}, "my-el", interceptor)
+		_, _, _, err := s.ExecuteInterceptors(trInt, &http.Request{URL: u}, []byte(`{}`), s.Logger, eventID, "trigger", namespace, map[string]interface{}{}, nil)
+		var respErr *interceptors.ResponseError
+		if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("ExecuteInterceptors() expected interceptor response error, got: %v", err)
+		}
+		if interceptor.calls != 1 {
+			t.Errorf("expected interceptor to be called once, got %d", interceptor.calls)
+		}
+	})
+}
+
+func TestSendDeadLetter(t *testing.T) {
+	var got []DeadLetter
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var dl DeadLetter
+		if err := json.NewDecoder(r.Body).Decode(&dl); err != nil {
+			t.Errorf("failed to decode dead letter: %v", err)
+		}
+		got = append(got, dl)
+	}))
+	defer srv.Close()
+
+	dls, err := apis.ParseURL(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+	s := Sink{
+		EventListenerName:      "my-el",
+		EventListenerNamespace: namespace,
+		HTTPClient:             srv.Client(),
+		WGProcessTriggers:      &sync.WaitGroup{},
+		DeadLetterSender:       NewDeadLetterSender(1),
+	}
+	policy := &triggersv1beta1.RetryPolicy{
+		Attempts:       1,
+		Backoff:        &metav1.Duration{Duration: time.Millisecond},
+		DeadLetterSink: dls,
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("X-Foo", "bar")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Hub-Signature-256", "sha256=secret")
+	req.Header.Set("X-Gitlab-Token", "secret")
+	req.Header.Set(DryRunTokenHeader, "secret")
+	dl := s.newDeadLetter(eventID, req, []byte(`{"foo":"bar"}`), errors.New("creation failed"))
+	dl.Trigger = "foo/my-trigger"
+	dl.Resources = []json.RawMessage{json.RawMessage(`{"kind":"TaskRun"}`)}
+
+	s.sendDeadLetter(policy, dl, zaptest.NewLogger(t).Sugar())
+	s.WGProcessTriggers.Wait()
+
+	want := []DeadLetter{{
+		EventListener: "my-el",
+		Namespace:     namespace,
+		EventID:       eventID,
+		Trigger:       "foo/my-trigger",
+		ErrorMessage:  "creation failed",
+		Header:        http.Header{"X-Foo": []string{"bar"}},
+		Body:          `{"foo":"bar"}`,
+		Resources:     []json.RawMessage{json.RawMessage(`{"kind":"TaskRun"}`)},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("sendDeadLetter() -want,+got: %s", diff)
+	}
+}
+
+func TestSendDeadLetter_Retry(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		status    int
+		wantCalls int
+	}{{
+		name:      "throttled",
+		status:    http.StatusTooManyRequests,
+		wantCalls: 3,
+	}, {
+		name:      "server error",
+		status:    http.StatusBadGateway,
+		wantCalls: 3,
+	}, {
+		name:      "bad request",
+		status:    http.StatusBadRequest,
+		wantCalls: 1,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+			dls, err := apis.ParseURL(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse URL: %v", err)
+			}
+			s := Sink{HTTPClient: srv.Client()}
+			policy := &triggersv1beta1.RetryPolicy{
+				Attempts:       2,
+				Backoff:        &metav1.Duration{Duration: time.Millisecond},
+				DeadLetterSink: dls,
+			}
+
+			if err := s.postDeadLetter(policy, []byte(`{}`)); err == nil {
+				t.Error("postDeadLetter() expected error")
+			}
+			if got := atomic.LoadInt32(&calls); int(got) != tc.wantCalls {
+				t.Errorf("expected dead letter sink to be called %d times, got %d", tc.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestSendDeadLetter_Bounded(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+	}))
+	defer srv.Close()
+	dls, err := apis.ParseURL(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+	s := Sink{
+		HTTPClient:        srv.Client(),
+		WGProcessTriggers: &sync.WaitGroup{},
+		DeadLetterSender:  NewDeadLetterSender(1),
+	}
+	policy := &triggersv1beta1.RetryPolicy{DeadLetterSink: dls}
+
+	s.sendDeadLetter(policy, DeadLetter{EventID: "1"}, zaptest.NewLogger(t).Sugar())
+	// The second dead letter is dropped rather than waiting for the first one.
+	s.sendDeadLetter(policy, DeadLetter{EventID: "2"}, zaptest.NewLogger(t).Sugar())
+	close(release)
+	s.WGProcessTriggers.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected dead letter sink to be called once, got %d", got)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/resources"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// rollbackTimeout bounds the deletion of the resources of a Trigger whose creation
+// failed. The resources are deleted even if the Trigger failed because it timed out.
+const rollbackTimeout = 30 * time.Second
+
+// rollback is the outcome of the creation of the resources of a Trigger that failed.
+type rollback struct {
+	// kept are the created resources that still exist.
+	kept []*unstructured.Unstructured
+	// deleted are the created resources that were rolled back.
+	deleted []*unstructured.Unstructured
+	// remaining are the resource templates whose resources do not exist.
+	remaining []json.RawMessage
+}
+
+// rollbackResources deletes the resources that the Trigger created for the event eventID
+// before the creation of its other resources failed, in the reverse order they were
+// created in. created are the resources created from the first resource templates in
+// res. Resources that are not labelled with the event ID, and resources written
+// with a create policy other than Create, might have existed before the event and are
+// kept.
+func (r Sink) rollbackResources(triggerNS, sa, eventID string, res []json.RawMessage, created []*unstructured.Unstructured, log *zap.SugaredLogger) rollback {
+	deleted := make([]bool, len(created))
+	dynamicClient, err := r.dynamicClientFor(sa, triggerNS, log)
+	if err != nil {
+		log.Errorf("failed to roll back created resources: %v", err)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+		defer cancel()
+		for i := len(created) - 1; i >= 0; i-- {
+			obj := created[i]
+			if obj.GetLabels()[triggers.GroupName+triggers.EventIDLabelKey] != eventID || !createdByEvent(res[i]) {
+				log.Infof("keeping %s %s/%s that might have existed before the event", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+				continue
+			}
+			if err := resources.Delete(ctx, obj, r.RESTMapper, dynamicClient); err != nil {
+				log.Errorf("failed to roll back %s %s/%s: %v", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+				continue
+			}
+			log.Infof("rolled back %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+			deleted[i] = true
+		}
+	}
+
+	var rb rollback
+	for i, rr := range res {
+		switch {
+		case i >= len(created):
+			rb.remaining = append(rb.remaining, rr)
+		case deleted[i]:
+			rb.deleted = append(rb.deleted, created[i])
+			rb.remaining = append(rb.remaining, rr)
+		default:
+			rb.kept = append(rb.kept, created[i])
+		}
+	}
+	return rb
+}
+
+// createdByEvent returns true if the resource of the resource template rr is created
+// for each event, rather than written over a resource that might already exist.
+func createdByEvent(rr json.RawMessage) bool {
+	var rt struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(rr, &rt); err != nil {
+		return false
+	}
+	policy, ok := rt.Metadata.Annotations[triggers.CreatePolicyAnnotation]
+	return !ok || triggersv1.CreatePolicy(policy) == triggersv1.CreatePolicyCreate
+}
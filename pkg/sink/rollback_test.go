@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/template"
+	"github.com/tektoncd/triggers/test"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+)
+
+// taskRunTemplate returns the resource template of a TaskRun named name with the
+// annotations.
+func taskRunTemplate(name string, annotations map[string]string) json.RawMessage {
+	rt, _ := json.Marshal(map[string]interface{}{
+		"apiVersion": "tekton.dev/v1beta1",
+		"kind":       "TaskRun",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace, "annotations": annotations},
+	})
+	return rt
+}
+
+func TestRollbackResources(t *testing.T) {
+	res := []json.RawMessage{
+		taskRunTemplate("created", nil),
+		taskRunTemplate("other-event", nil),
+		taskRunTemplate("updated", map[string]string{triggers.CreatePolicyAnnotation: "CreateOrUpdate"}),
+		taskRunTemplate("failed", nil),
+	}
+	s, dynamicClient := getSinkAssets(t, test.Resources{}, "my-el", nil)
+	var created []*unstructured.Unstructured
+	for i, rr := range res[:3] {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(rr); err != nil {
+			t.Fatal(err)
+		}
+		id := eventID
+		if i == 1 {
+			id = "another-event"
+		}
+		obj.SetLabels(map[string]string{triggers.GroupName + triggers.EventIDLabelKey: id})
+		if _, err := dynamicClient.Resource(taskRunsGVR).Namespace(namespace).Create(context.Background(), obj, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, obj)
+	}
+
+	rb := s.rollbackResources(namespace, "", eventID, res, created, s.Logger)
+
+	names := func(objs []*unstructured.Unstructured) []string {
+		var names []string
+		for _, obj := range objs {
+			names = append(names, obj.GetName())
+		}
+		return names
+	}
+	if diff := cmp.Diff([]string{"created"}, names(rb.deleted)); diff != "" {
+		t.Errorf("unexpected rolled back resources -want +got: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"other-event", "updated"}, names(rb.kept)); diff != "" {
+		t.Errorf("unexpected kept resources -want +got: %s", diff)
+	}
+	if diff := cmp.Diff([]json.RawMessage{res[0], res[3]}, rb.remaining); diff != "" {
+		t.Errorf("unexpected remaining resource templates -want +got: %s", diff)
+	}
+	for _, obj := range created {
+		_, err := dynamicClient.Resource(taskRunsGVR).Namespace(namespace).Get(context.Background(), obj.GetName(), metav1.GetOptions{})
+		if exists, wantExists := err == nil, obj.GetName() != "created"; exists != wantExists {
+			t.Errorf("TaskRun %s exists = %t after rollback, want %t", obj.GetName(), exists, wantExists)
+		}
+	}
+}
+
+func TestHandleEvent_Atomic(t *testing.T) {
+	for _, atomic := range []bool{true, false} {
+		t.Run(fmt.Sprintf("atomic %t", atomic), func(t *testing.T) {
+			tt := &triggersv1beta1.TriggerTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "runs", Namespace: namespace},
+				Spec: triggersv1beta1.TriggerTemplateSpec{
+					ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+						RawExtension: runtime.RawExtension{Raw: taskRunTemplate("first", nil)},
+					}, {
+						RawExtension: runtime.RawExtension{Raw: taskRunTemplate("second", nil)},
+					}},
+				},
+			}
+			if atomic {
+				tt.Annotations = map[string]string{template.AtomicAnnotation: "true"}
+			}
+			el := &triggersv1beta1.EventListener{
+				ObjectMeta: metav1.ObjectMeta{Name: "atomic-el", Namespace: namespace, UID: types.UID(elUID)},
+				Spec: triggersv1beta1.EventListenerSpec{
+					Triggers: []triggersv1beta1.EventListenerTrigger{{
+						Name:     "runs",
+						Template: &triggersv1beta1.EventListenerTemplate{Ref: &tt.Name},
+					}},
+				},
+			}
+			s, dynamicClient := getSinkAssets(t, test.Resources{
+				TriggerTemplates: []*triggersv1beta1.TriggerTemplate{tt},
+				EventListeners:   []*triggersv1beta1.EventListener{el},
+			}, el.Name, nil)
+			dynamicClient.PrependReactor("create", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+				if action.(ktesting.CreateAction).GetObject().(*unstructured.Unstructured).GetName() == "second" {
+					return true, nil, kerrors.NewBadRequest("invalid TaskRun")
+				}
+				return false, nil, nil
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(SyncResponseHeader, "true")
+			resp := httptest.NewRecorder()
+			s.HandleEvent(resp, req)
+			s.WGProcessTriggers.Wait()
+
+			var body Response
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response %q: %v", resp.Body.String(), err)
+			}
+			if len(body.Triggers) != 1 {
+				t.Fatalf("expected the result of one Trigger, got %+v", body.Triggers)
+			}
+			got := body.Triggers[0]
+			first := []CreatedResource{{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun", Namespace: namespace, Name: "first"}}
+			wantResources, wantRolledBack := first, []CreatedResource(nil)
+			if atomic {
+				wantResources, wantRolledBack = nil, first
+			}
+			if diff := cmp.Diff(wantResources, got.Resources); diff != "" {
+				t.Errorf("unexpected created resources -want +got: %s", diff)
+			}
+			if diff := cmp.Diff(wantRolledBack, got.RolledBack); diff != "" {
+				t.Errorf("unexpected rolled back resources -want +got: %s", diff)
+			}
+			_, err := dynamicClient.Resource(taskRunsGVR).Namespace(namespace).Get(context.Background(), "first", metav1.GetOptions{})
+			if exists := err == nil; exists == atomic {
+				t.Errorf("TaskRun first exists = %t after the creation of the resources failed (get error: %v)", exists, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// interruptedEventTimeout bounds the time spent reporting the events that were still
+// being processed when the shutdown grace period ran out.
+const interruptedEventTimeout = 2 * time.Second
+
+// InFlightEvents keeps track of the events whose triggers are still being processed.
+// A nil InFlightEvents tracks nothing.
+type InFlightEvents struct {
+	mu     sync.Mutex
+	events map[*eventProcessing]int
+}
+
+// NewInFlightEvents returns an empty InFlightEvents.
+func NewInFlightEvents() *InFlightEvents {
+	return &InFlightEvents{events: map[*eventProcessing]int{}}
+}
+
+// add records n more pieces of work being processed for ev.
+func (f *InFlightEvents) add(ev *eventProcessing, n int) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events[ev] += n
+	if f.events[ev] <= 0 {
+		delete(f.events, ev)
+	}
+}
+
+// pending returns the events that are still being processed, along with the number of
+// pieces of work left for each of them.
+func (f *InFlightEvents) pending() map[*eventProcessing]int {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pending := make(map[*eventProcessing]int, len(f.events))
+	for ev, n := range f.events {
+		pending[ev] = n
+	}
+	return pending
+}
+
+// Drain waits for the triggers that are being processed to finish, until ctx is done.
+// The events that are still being processed at that point are logged and reported to the
+// CloudEventURI of the EventListener with a ProcessingInterruptedType CloudEvent, and
+// the error of ctx is returned.
+func (r Sink) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.WGProcessTriggers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	sendCtx, cancel := context.WithTimeout(context.Background(), interruptedEventTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for ev, n := range r.InFlight.pending() {
+		log := r.Logger.With("eventlistener", r.EventListenerName, "namespace", r.EventListenerNamespace, "eventID", ev.eventID)
+		log.Errorf("EventListener shut down with %d triggers of the event still being processed", n)
+		if r.CloudEventClient == nil || ev.cloudEventURI == nil {
+			continue
+		}
+		e, err := r.newLifecycleEvent(ev, ProcessingInterruptedType, nil)
+		if err != nil {
+			log.Errorf("failed to encode %s CloudEvent: %v", ProcessingInterruptedType, err)
+			continue
+		}
+		target := ev.cloudEventURI.String()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res := r.CloudEventClient.Send(cloudevents.ContextWithTarget(sendCtx, target), e); !cloudevents.IsACK(res) {
+				log.Errorf("failed to send %s CloudEvent to %s: %v", ProcessingInterruptedType, target, res)
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/tektoncd/triggers/test"
+	"knative.dev/pkg/apis"
+)
+
+func TestDrain(t *testing.T) {
+	sink, _ := getSinkAssets(t, test.Resources{}, "lifecycle-el", nil)
+	sink.InFlight = NewInFlightEvents()
+	ev := &eventProcessing{eventID: "finished"}
+	finished := make(chan struct{})
+	go sink.track(ev, func() { <-finished })()
+	close(finished)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sink.Drain(ctx); err != nil {
+		t.Fatalf("Drain() unexpected error: %v", err)
+	}
+	if pending := sink.InFlight.pending(); len(pending) != 0 {
+		t.Errorf("expected no events in flight, got %d", len(pending))
+	}
+}
+
+func TestDrain_GracePeriodExpired(t *testing.T) {
+	receiver := &lifecycleReceiver{t: t}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+	sinkURI, err := apis.ParseURL(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink, _ := getSinkAssets(t, test.Resources{}, "lifecycle-el", nil)
+	sink.InFlight = NewInFlightEvents()
+	sink.CloudEventClient, err = cloudevents.NewClientHTTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &eventProcessing{eventID: "stuck", eventListenerUID: elUID, cloudEventURI: sinkURI}
+	stuck := make(chan struct{})
+	defer close(stuck)
+	go sink.track(ev, func() { <-stuck })()
+	go sink.track(ev, func() { <-stuck })()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := sink.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Drain() expected context.DeadlineExceeded, got: %v", err)
+	}
+	if n := sink.InFlight.pending()[ev]; n != 2 {
+		t.Errorf("expected 2 triggers of the event in flight, got %d", n)
+	}
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if len(receiver.events) != 1 {
+		t.Fatalf("expected one CloudEvent, got %+v", receiver.events)
+	}
+	got := receiver.events[0]
+	if got.Type != ProcessingInterruptedType || got.Data.EventID != "stuck" || got.Data.EventListenerUID != elUID {
+		t.Errorf("unexpected CloudEvent: %+v", got)
+	}
+}
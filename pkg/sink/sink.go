@@ -23,9 +23,13 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/tektoncd/triggers/pkg/apis/triggers"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
@@ -36,12 +40,31 @@ import (
 	"github.com/tektoncd/triggers/pkg/resources"
 	"github.com/tektoncd/triggers/pkg/template"
 	"github.com/tidwall/sjson"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
-	discoveryclient "k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// SyncResponseHeader can be set to "true" on an incoming request to make
+	// the EventListener wait for all triggers to be processed before responding.
+	SyncResponseHeader = "Tekton-Triggers-Sync-Response"
+
+	// retryAfterSeconds is the delay sent to clients whose events are rejected
+	// because the processing queue is full.
+	retryAfterSeconds = "5"
 )
 
 var (
@@ -51,9 +74,12 @@ var (
 // Sink defines the sink resource for processing incoming events for the
 // EventListener.
 type Sink struct {
-	KubeClientSet          kubernetes.Interface
-	TriggersClient         triggersclientset.Interface
-	DiscoveryClient        discoveryclient.ServerResourcesInterface
+	KubeClientSet  kubernetes.Interface
+	TriggersClient triggersclientset.Interface
+	// RESTMapper maps the kinds of the resources created by triggers to their resources.
+	// It is shared by all triggers, as the resources served by the API server do not
+	// depend on the service account that creates them.
+	RESTMapper             meta.RESTMapper
 	DynamicClient          dynamic.Interface
 	HTTPClient             *http.Client
 	EventListenerName      string
@@ -62,9 +88,45 @@ type Sink struct {
 	Recorder               *Recorder
 	Auth                   AuthOverride
 	PayloadValidation      bool
+	// MaxBodySize is the maximum size in bytes of request bodies. If 0, bodies of any
+	// size are accepted.
+	MaxBodySize int64
+	// AllowedContentTypes are the media types that requests may send. If empty, any
+	// content type is accepted.
+	AllowedContentTypes []string
+	// AllowedSourceRanges are the IP ranges that requests may be sent from. If empty,
+	// requests are accepted from any IP.
+	AllowedSourceRanges []*net.IPNet
+	// TrustedProxies are the IP ranges of the proxies whose X-Forwarded-For header is
+	// used to find the IP of the client that sent a request.
+	TrustedProxies []*net.IPNet
 	// WGProcessTriggers keeps track of triggers or triggerGroups currently being processed
-	// Currently only used in tests to wait for all triggers to finish processing
+	// It is waited on by Drain so that triggers can finish processing on shutdown.
 	WGProcessTriggers *sync.WaitGroup
+	// InFlight keeps track of the events being processed, so that the events that are
+	// interrupted by a shutdown can be reported. If nil, events are not tracked.
+	InFlight *InFlightEvents
+	// WorkerPool bounds the number of triggers processed concurrently.
+	// If nil, each trigger is processed in its own goroutine.
+	WorkerPool *WorkerPool
+	// Deduplicator records delivered events for EventListeners that enable deduplication.
+	// If nil, events are never deduplicated.
+	Deduplicator *Deduplicator
+	// ConcurrencyGroups controls the runs of Triggers that set a concurrency group.
+	// If nil, the concurrency of runs is not controlled.
+	ConcurrencyGroups *ConcurrencyGroups
+	// DeadLetterSender bounds the number of dead letters sent at once. If nil, every
+	// dead letter is sent in its own goroutine.
+	DeadLetterSender *DeadLetterSender
+	// CloudEventClient sends CloudEvents to the CloudEventURI of the EventListener
+	// as events are processed. If nil, no CloudEvents are sent.
+	CloudEventClient cloudevents.Client
+	// DryRunToken is the token that dry run requests must send to EventListeners that
+	// authorize dry runs with a token.
+	DryRunToken string
+	// EventRecorder emits Kubernetes Events about Triggers that fail to be processed.
+	// If nil, no Events are emitted.
+	EventRecorder record.EventRecorder
 
 	// listers index properties about resources
 	EventListenerLister         listers.EventListenerLister
@@ -89,10 +151,103 @@ type Response struct {
 	EventID string `json:"eventID,omitempty"`
 	// ErrorMessage gives message about Error which occurs during event processing
 	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Duplicate is true if the event was dropped because it was already delivered.
+	Duplicate bool `json:"duplicate,omitempty"`
+	// DryRun is true if the event was processed without creating any resources.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Triggers lists the outcome of each trigger that processed the event.
+	// It is only populated when the EventListener responds synchronously, or for dry runs.
+	Triggers []TriggerResult `json:"triggers,omitempty"`
+}
+
+// TriggerResult describes the outcome of processing an event for a single Trigger.
+// When the interceptors of a TriggerGroup stop processing, a single result
+// with only TriggerGroup set is reported for the whole group.
+type TriggerResult struct {
+	// Name is the name of the Trigger.
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the Trigger.
+	Namespace string `json:"namespace,omitempty"`
+	// TriggerGroup is the name of the TriggerGroup the Trigger was selected by, if any.
+	TriggerGroup string `json:"triggerGroup,omitempty"`
+	// Continue is false if an interceptor stopped processing the Trigger.
+	Continue bool `json:"continue"`
+	// Status is the status returned by the interceptor that stopped processing.
+	Status *triggersv1.Status `json:"status,omitempty"`
+	// Resources are the resources created for the Trigger.
+	Resources []CreatedResource `json:"resources,omitempty"`
+	// RolledBack are the resources that were created for the Trigger and deleted
+	// because the creation of its other resources failed.
+	RolledBack []CreatedResource `json:"rolledBack,omitempty"`
+	// RenderedResources are the resources that would have been created for the
+	// Trigger. They are only set for dry runs.
+	RenderedResources []json.RawMessage `json:"renderedResources,omitempty"`
+	// Skipped is true if no resources were created because runs of the concurrency
+	// group of the event were in progress.
+	Skipped bool `json:"skipped,omitempty"`
+	// ErrorMessage gives message about Error which occurs while processing the Trigger
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// CreatedResource identifies a resource created from a TriggerTemplate.
+type CreatedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// eventProcessing keeps track of the triggers processed for a single event.
+type eventProcessing struct {
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	results []TriggerResult
+
+	eventID          string
+	eventListenerUID string
+	// cloudEventURI is where lifecycle CloudEvents for the event are sent, if set.
+	cloudEventURI *apis.URL
+	// triggerInvocations is set if the results are recorded as TriggerInvocations.
+	triggerInvocations *triggersv1.TriggerInvocationPolicy
+	// dryRun is true if resources are rendered instead of created.
+	dryRun bool
+}
+
+// failed returns true if processing the event failed for any Trigger or TriggerGroup.
+func (e *eventProcessing) failed() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, res := range e.results {
+		if res.ErrorMessage != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *eventProcessing) addResult(res TriggerResult) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.results = append(e.results, res)
+}
+
+// addResult records the result of processing the event for a Trigger or TriggerGroup,
+// sends a lifecycle CloudEvent of the given type for it, and records inv as a TriggerInvocation.
+func (r Sink) addResult(ev *eventProcessing, eventType string, res TriggerResult, inv *invocation, log *zap.SugaredLogger) {
+	ev.addResult(res)
+	r.emitLifecycleEvent(ev, eventType, &res, log)
+	r.recordTriggerInvocation(ev, res, inv, log)
 }
 
 // HandleEvent processes an incoming HTTP event for the event listener.
 func (r Sink) HandleEvent(response http.ResponseWriter, request *http.Request) {
+	request, span := startEventSpan(request)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("eventlistener", r.EventListenerName),
+		attribute.String("namespace", r.EventListenerNamespace),
+	)
 	log := r.Logger.With(
 		zap.String("eventlistener", r.EventListenerName),
 		zap.String("namespace", r.EventListenerNamespace),
@@ -116,9 +271,41 @@ func (r Sink) HandleEvent(response http.ResponseWriter, request *http.Request) {
 
 	eventID := template.UUID()
 	log = log.With(zap.String(triggers.EventIDLabelKey, eventID))
+	span.SetAttributes(attribute.String("eventID", eventID))
+	dryRun := isDryRun(request)
+	if dryRun {
+		if err := r.authorizeDryRun(request.Context(), el, request); err != nil {
+			log.Warnf("refusing dry run: %s", err)
+			r.recordCountMetrics(failTag)
+			r.writeResponse(response, dryRunStatus(err), Response{
+				EventListener:    r.EventListenerName,
+				EventListenerUID: elUID,
+				Namespace:        r.EventListenerNamespace,
+				EventID:          eventID,
+				ErrorMessage:     err.Error(),
+			}, log)
+			return
+		}
+		log = log.With(zap.Bool("dryRun", true))
+	}
+	event, err = normalizeCloudEvent(request, event)
+	if err != nil {
+		log.Errorf("Error reading CloudEvent: %s", err)
+		r.recordCountMetrics(failTag)
+		r.writeResponse(response, http.StatusBadRequest, Response{
+			EventListener:    r.EventListenerName,
+			EventListenerUID: elUID,
+			Namespace:        r.EventListenerNamespace,
+			EventID:          eventID,
+			ErrorMessage:     err.Error(),
+		}, log)
+		return
+	}
 	log.Debugf("handling event with path %s, payload: %s and header: %v", request.URL.Path, string(event), request.Header)
+	_, selectSpan := startSpan(request.Context(), selectTriggersSpanName)
 	trItems, err := r.selectTriggers(el.Spec.NamespaceSelector, el.Spec.LabelSelector)
 	if err != nil {
+		endSpan(selectSpan, err)
 		r.Logger.Errorf("unable to select configured mergedTriggers: %s", err)
 		response.WriteHeader(http.StatusInternalServerError)
 		return
@@ -127,43 +314,252 @@ func (r Sink) HandleEvent(response http.ResponseWriter, request *http.Request) {
 	// Process any ungroupedTriggers
 	mergedTriggers, err := r.merge(el.Spec.Triggers, trItems)
 	if err != nil {
+		endSpan(selectSpan, err)
 		log.Errorf("error merging triggers: %s", err)
 		response.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	r.WGProcessTriggers.Add(len(mergedTriggers))
-	for _, t := range mergedTriggers {
-		go func(t triggersv1.Trigger) {
-			defer r.WGProcessTriggers.Done()
-			localRequest := request.Clone(request.Context())
-			r.processTrigger(t, localRequest, event, eventID, log, emptyExtensions)
-		}(*t)
+	// Only process the triggers and trigger groups whose path matches the request
+	matchedTriggers := triggersForPath(mergedTriggers, request.URL.Path)
+	matchedGroups := triggerGroupsForPath(el.Spec.TriggerGroups, request.URL.Path)
+	selectSpan.SetAttributes(
+		attribute.Int64("triggers", int64(len(matchedTriggers))),
+		attribute.Int64("triggerGroups", int64(len(matchedGroups))),
+	)
+	selectSpan.End()
+	if len(matchedTriggers) == 0 && len(matchedGroups) == 0 && (len(mergedTriggers) > 0 || len(el.Spec.TriggerGroups) > 0) {
+		log.Infof("no triggers match path %s", request.URL.Path)
+		r.recordCountMetrics(failTag)
+		r.writeResponse(response, http.StatusNotFound, Response{
+			EventListener:    r.EventListenerName,
+			EventListenerUID: elUID,
+			Namespace:        r.EventListenerNamespace,
+			EventID:          eventID,
+			ErrorMessage:     fmt.Sprintf("no triggers match path %s", request.URL.Path),
+		}, log)
+		return
+	}
+
+	// Dry runs are not deliveries of the event, and neither drop nor are dropped as duplicates.
+	var dedupKey string
+	var duplicate bool
+	if !dryRun {
+		dedupKey, duplicate = r.markDelivered(el, request, event, log)
+	}
+	if duplicate {
+		r.recordCountMetrics(duplicateTag)
+		r.writeResponse(response, http.StatusOK, Response{
+			EventListener:    r.EventListenerName,
+			EventListenerUID: elUID,
+			Namespace:        r.EventListenerNamespace,
+			EventID:          eventID,
+			Duplicate:        true,
+		}, log)
+		return
+	}
+
+	ev := &eventProcessing{
+		eventID:          eventID,
+		eventListenerUID: elUID,
+		dryRun:           dryRun,
+	}
+	if !dryRun {
+		ev.cloudEventURI = el.Spec.CloudEventURI
+		ev.triggerInvocations = el.Spec.TriggerInvocations
+	}
+	// The triggers can still be processed after the EventListener responds, so they are
+	// processed with a context that carries the values of the request but is not done with it.
+	ctx, cancel := withTimeout(detachedContext{request.Context()}, eventTimeoutScope, "", durationOf(el.Spec.ProcessingTimeout))
+	var work []func()
+	for _, t := range matchedTriggers {
+		t := *t
+		if t.Spec.RetryPolicy == nil {
+			t.Spec.RetryPolicy = el.Spec.RetryPolicy
+		}
+		work = append(work, func() {
+			localRequest := request.Clone(ctx)
+			r.processTrigger(t, newInvocation(""), localRequest, event, eventID, log, emptyExtensions, ev)
+		})
 	}
 
 	// Process grouped triggers
-	for _, group := range el.Spec.TriggerGroups {
-		r.WGProcessTriggers.Add(1)
-		go func(g triggersv1.EventListenerTriggerGroup) {
-			defer r.WGProcessTriggers.Done()
-			localRequest := request.Clone(request.Context())
-			r.processTriggerGroups(g, localRequest, event, eventID, log, r.WGProcessTriggers)
-		}(group)
+	for _, g := range matchedGroups {
+		g := g
+		work = append(work, func() {
+			localRequest := request.Clone(ctx)
+			r.processTriggerGroups(g, el.Spec.RetryPolicy, localRequest, event, eventID, log, ev)
+		})
 	}
 
-	r.recordCountMetrics(successTag)
-	response.WriteHeader(http.StatusAccepted)
-	response.Header().Set("Content-Type", "application/json")
+	r.emitLifecycleEvent(ev, EventReceivedType, nil, log)
+	if err := r.processAsync(ev, work...); err != nil {
+		cancel()
+		log.Warnf("rejecting event: %s", err)
+		if dedupKey != "" {
+			// The event source redelivers rejected events, which must not be dropped as duplicates.
+			r.forgetDelivery(el.Spec.Deduplication, dedupKey, log)
+		}
+		r.recordCountMetrics(rejectedTag)
+		response.Header().Set("Retry-After", retryAfterSeconds)
+		r.writeResponse(response, http.StatusServiceUnavailable, Response{
+			EventListener:    r.EventListenerName,
+			EventListenerUID: elUID,
+			Namespace:        r.EventListenerNamespace,
+			EventID:          eventID,
+			ErrorMessage:     err.Error(),
+		}, log)
+		return
+	}
+	// The delivery is only kept once the event was processed, so that the event source can
+	// redeliver events whose processing failed.
+	r.WGProcessTriggers.Add(1)
+	go func() {
+		defer r.WGProcessTriggers.Done()
+		ev.wg.Wait()
+		cancel()
+		if dedupKey != "" && ev.failed() {
+			r.forgetDelivery(el.Spec.Deduplication, dedupKey, log)
+		}
+	}()
+
+	status := http.StatusAccepted
 	body := Response{
 		EventListener:    r.EventListenerName,
 		EventListenerUID: elUID,
 		Namespace:        r.EventListenerNamespace,
 		EventID:          eventID,
+		DryRun:           dryRun,
 	}
+	if dryRun || isSyncResponse(el, request) {
+		ev.wg.Wait()
+		status = http.StatusOK
+		body.Triggers = ev.results
+		if body.Triggers == nil {
+			body.Triggers = []TriggerResult{}
+		}
+	}
+
+	r.recordCountMetrics(successTag)
+	r.writeResponse(response, status, body, log)
+}
+
+// writeResponse writes body as the JSON response to an event.
+func (r Sink) writeResponse(response http.ResponseWriter, status int, body Response, log *zap.SugaredLogger) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
 	if err := json.NewEncoder(response).Encode(body); err != nil {
 		log.Errorf("failed to write back sink response: %v", err)
 	}
 }
 
+// markDelivered records the delivery of the event if the EventListener enables
+// deduplication. It returns the deduplication key of the event if it was recorded,
+// and true if the event was already delivered.
+func (r Sink) markDelivered(el *triggersv1.EventListener, request *http.Request, event []byte, log *zap.SugaredLogger) (string, bool) {
+	dedup := el.Spec.Deduplication
+	if dedup == nil || r.Deduplicator == nil {
+		return "", false
+	}
+	key, err := template.ResolveValue(dedup.Key, event, request.Header, emptyExtensions)
+	if err != nil {
+		log.Warnf("not deduplicating event without deduplication key: %v", err)
+		return "", false
+	}
+	duplicate, err := r.Deduplicator.MarkDelivered(request.Context(), dedup, key)
+	if err != nil {
+		// Processing a duplicate is better than dropping an event.
+		log.Errorf("failed to record delivery of event %q: %v", key, err)
+		return "", false
+	}
+	if duplicate {
+		log.Infof("dropping duplicate event %q", key)
+		return "", true
+	}
+	return key, false
+}
+
+// forgetDelivery removes the record of the delivery of the event with key, so that the
+// event is processed again if it is redelivered.
+func (r Sink) forgetDelivery(dedup *triggersv1.Deduplication, key string, log *zap.SugaredLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), forgetDeliveryTimeout)
+	defer cancel()
+	if err := r.Deduplicator.Forget(ctx, dedup, key); err != nil {
+		log.Errorf("failed to forget delivery of event %q: %v", key, err)
+		return
+	}
+	log.Infof("forgot delivery of event %q so that it can be redelivered", key)
+}
+
+// concurrencyGroup returns the concurrency group of the event for t, whose resolved
+// resources are resources, or nil if the key of the group cannot be resolved.
+func (r Sink) concurrencyGroup(t triggersv1.Trigger, body []byte, header http.Header, extensions map[string]interface{}, resources []json.RawMessage, log *zap.SugaredLogger) *concurrencyGroup {
+	key, err := template.ResolveValue(t.Spec.Concurrency.Key, body, header, extensions)
+	if err != nil {
+		// Creating the runs of an event outside of its group is better than dropping it.
+		log.Warnf("not controlling concurrency of event without concurrency key: %v", err)
+		return nil
+	}
+	return newConcurrencyGroup(t, key, resources)
+}
+
+// isSyncResponse returns true if the EventListener should wait for all triggers
+// to be processed before responding to the request.
+func isSyncResponse(el *triggersv1.EventListener, request *http.Request) bool {
+	if v, ok := el.GetAnnotations()[triggers.SyncResponseAnnotation]; ok {
+		return v == "true"
+	}
+	return strings.EqualFold(request.Header.Get(SyncResponseHeader), "true")
+}
+
+// processAsync processes each of fns in the background and tracks them in both
+// WGProcessTriggers and the wait group of the event. If the WorkerPool does not
+// have room for all of fns, none of them are processed and ErrQueueFull is returned.
+func (r Sink) processAsync(ev *eventProcessing, fns ...func()) error {
+	tracked := make([]func(), len(fns))
+	for i, fn := range fns {
+		tracked[i] = r.track(ev, fn)
+	}
+	if r.WorkerPool == nil {
+		for _, fn := range tracked {
+			go fn()
+		}
+		return nil
+	}
+	if err := r.WorkerPool.SubmitAll(tracked...); err != nil {
+		r.WGProcessTriggers.Add(-len(fns))
+		ev.wg.Add(-len(fns))
+		r.InFlight.add(ev, -len(fns))
+		return err
+	}
+	return nil
+}
+
+// processNestedAsync processes fn in the background like processAsync. It is used
+// for work that belongs to an event that was already accepted, so if the WorkerPool
+// is full fn is processed on the calling goroutine instead of being rejected.
+func (r Sink) processNestedAsync(ev *eventProcessing, fn func()) {
+	tracked := r.track(ev, fn)
+	if r.WorkerPool == nil {
+		go tracked()
+		return
+	}
+	r.WorkerPool.SubmitOrRun(tracked)
+}
+
+// track adds fn to WGProcessTriggers, the wait group of the event and InFlight, and returns
+// a function that processes fn and marks it as done.
+func (r Sink) track(ev *eventProcessing, fn func()) func() {
+	r.WGProcessTriggers.Add(1)
+	ev.wg.Add(1)
+	r.InFlight.add(ev, 1)
+	return func() {
+		defer r.WGProcessTriggers.Done()
+		defer ev.wg.Done()
+		defer r.InFlight.add(ev, -1)
+		fn()
+	}
+}
+
 func (r Sink) merge(et []triggersv1.EventListenerTrigger, trItems []*triggersv1.Trigger) ([]*triggersv1.Trigger, error) {
 	triggers := trItems
 	for _, t := range et {
@@ -174,6 +570,12 @@ func (r Sink) merge(et []triggersv1.EventListenerTrigger, trItems []*triggersv1.
 				r.Logger.Errorf("Error getting Trigger %s in Namespace %s: %s", t.TriggerRef, r.EventListenerNamespace, err)
 				continue
 			}
+			if t.Path != "" {
+				// The path of the EventListener overrides the path of the referenced Trigger
+				trig = trig.DeepCopy()
+				trig.Spec.Path = t.Path
+				trig.Spec.PathType = t.PathType
+			}
 			triggers = append(triggers, trig)
 		case t.Template != nil:
 			triggers = append(triggers, &triggersv1.Trigger{
@@ -185,6 +587,12 @@ func (r Sink) merge(et []triggersv1.EventListenerTrigger, trItems []*triggersv1.
 					Bindings:           t.Bindings,
 					Template:           *t.Template,
 					Interceptors:       t.Interceptors,
+					RetryPolicy:        t.RetryPolicy,
+					Timeout:            t.Timeout,
+					Path:               t.Path,
+					PathType:           t.PathType,
+					Concurrency:        t.Concurrency,
+					Retention:          t.Retention,
 				},
 			})
 		default:
@@ -194,13 +602,30 @@ func (r Sink) merge(et []triggersv1.EventListenerTrigger, trItems []*triggersv1.
 	return triggers, nil
 }
 
-func (r Sink) processTriggerGroups(g triggersv1.EventListenerTriggerGroup, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger, wg *sync.WaitGroup) {
+// processTriggerGroups processes the Triggers selected by g. retryPolicy is the
+// RetryPolicy of the EventListener, used for the group and any selected Triggers
+// that do not set their own.
+func (r Sink) processTriggerGroups(g triggersv1.EventListenerTriggerGroup, retryPolicy *triggersv1.RetryPolicy, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger, ev *eventProcessing) {
 	log := eventLog.With(zap.String(triggers.TriggerGroupLabelKey, g.Name))
+	ctx, span := startSpan(r.withTriggerTags(request.Context(), "", g.Name), processTriggerGroupSpanName)
+	defer span.End()
+	span.SetAttributes(attribute.String("triggerGroup", g.Name))
+	request = request.WithContext(ctx)
 
 	extensions := map[string]interface{}{}
-	payload, header, resp, err := r.ExecuteInterceptors(g.Interceptors, request, event, log, eventID, fmt.Sprintf("namespaces/%s/triggerGroups/%s", r.EventListenerNamespace, g.Name), r.EventListenerNamespace, extensions)
+	inv := newInvocation(g.Name)
+	payload, header, resp, err := r.executeInterceptors(g.Interceptors, request, event, log, eventID, fmt.Sprintf("namespaces/%s/triggerGroups/%s", r.EventListenerNamespace, g.Name), r.EventListenerNamespace, extensions, retryPolicy, inv)
 	if err != nil {
 		log.Error(err)
+		r.recordTimeout(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		r.recordEventListenerFailure(ev, InterceptorFailedReason, log, "TriggerGroup %s failed to process event %s: %v", g.Name, eventID, err)
+		r.addResult(ev, CreationFailedType, TriggerResult{TriggerGroup: g.Name, ErrorMessage: err.Error()}, inv, log)
+		if !ev.dryRun {
+			dl := r.newDeadLetter(eventID, request, event, err)
+			dl.TriggerGroup = g.Name
+			r.sendDeadLetter(retryPolicy, dl, log)
+		}
 		return
 	}
 	if resp != nil {
@@ -211,12 +636,14 @@ func (r Sink) processTriggerGroups(g triggersv1.EventListenerTriggerGroup, reque
 		}
 		if !resp.Continue {
 			eventLog.Infof("interceptor stopped trigger processing: %v", resp.Status.Err())
+			r.addResult(ev, InterceptorRejectedType, TriggerResult{TriggerGroup: g.Name, Status: &resp.Status}, inv, log)
 			return
 		}
 	}
 
 	trItems, err := r.selectTriggers(g.TriggerSelector.NamespaceSelector, g.TriggerSelector.LabelSelector)
 	if err != nil {
+		r.addResult(ev, CreationFailedType, TriggerResult{TriggerGroup: g.Name, ErrorMessage: err.Error()}, inv, log)
 		return
 	}
 
@@ -226,18 +653,50 @@ func (r Sink) processTriggerGroups(g triggersv1.EventListenerTriggerGroup, reque
 	triggerReq.Header = header
 	triggerReq.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
 
-	wg.Add(len(trItems))
-	for _, t := range trItems {
-		go func(t triggersv1.Trigger) {
-			defer wg.Done()
+	for _, t := range triggersForPath(trItems, request.URL.Path) {
+		t := *t
+		if t.Spec.RetryPolicy == nil {
+			t.Spec.RetryPolicy = retryPolicy
+		}
+		r.processNestedAsync(ev, func() {
 			// TODO(dibyom): We might be able to get away with only cloning if necessary
 			// i.e. if there are interceptors and iff those interceptors will modify the body/header (i.e. webhook)
 			localRequest := triggerReq.Clone(triggerReq.Context())
-			r.processTrigger(t, localRequest, event, eventID, log, extensions)
-		}(*t)
+			r.processTrigger(t, inv.forTrigger(), localRequest, event, eventID, log, extensions, ev)
+		})
 	}
 }
 
+// eventListenerTriggers returns the Triggers of the EventListener el and of its
+// TriggerGroups. A Trigger selected by several of them is returned once.
+func (r Sink) eventListenerTriggers(el *triggersv1.EventListener) ([]*triggersv1.Trigger, error) {
+	trItems, err := r.selectTriggers(el.Spec.NamespaceSelector, el.Spec.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select triggers: %w", err)
+	}
+	for _, g := range el.Spec.TriggerGroups {
+		groupItems, err := r.selectTriggers(g.TriggerSelector.NamespaceSelector, g.TriggerSelector.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select triggers of TriggerGroup %s: %w", g.Name, err)
+		}
+		trItems = append(trItems, groupItems...)
+	}
+	mergedTriggers, err := r.merge(el.Spec.Triggers, trItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge triggers: %w", err)
+	}
+	var ts []*triggersv1.Trigger
+	seen := map[string]bool{}
+	for _, t := range mergedTriggers {
+		id := t.Namespace + "/" + t.Name
+		if !seen[id] {
+			seen[id] = true
+			ts = append(ts, t)
+		}
+	}
+	return ts, nil
+}
+
 func (r Sink) selectTriggers(namespaceSelector triggersv1.NamespaceSelector, labelSelector *metav1.LabelSelector) ([]*triggersv1.Trigger, error) {
 	var trItems []*triggersv1.Trigger
 	var err error
@@ -287,59 +746,175 @@ func (r Sink) selectTriggers(namespaceSelector triggersv1.NamespaceSelector, lab
 	return trItems, nil
 }
 
-func (r Sink) processTrigger(t triggersv1.Trigger, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger, extensions map[string]interface{}) {
+// processTrigger processes the event for t, records how it was processed in inv,
+// and adds the result to ev. Processing stops once the context of request is done
+// or the timeout of t elapses.
+func (r Sink) processTrigger(t triggersv1.Trigger, inv *invocation, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger, extensions map[string]interface{}, ev *eventProcessing) {
 	log := eventLog.With(zap.String(triggers.TriggerLabelKey, t.Name))
+	ctx, cancel := withTimeout(r.withTriggerTags(request.Context(), t.Name, inv.triggerGroup), triggerTimeoutScope, t.Name, durationOf(t.Spec.Timeout))
+	defer cancel()
+	ctx, span := startSpan(ctx, processTriggerSpanName)
+	span.SetAttributes(
+		attribute.String("trigger", t.Name),
+		attribute.String("namespace", t.Namespace),
+	)
+	request = request.WithContext(ctx)
+	result := TriggerResult{
+		Name:         t.Name,
+		Namespace:    t.Namespace,
+		TriggerGroup: inv.triggerGroup,
+	}
+	defer func() {
+		if result.ErrorMessage != "" {
+			span.SetStatus(otelcodes.Error, result.ErrorMessage)
+		}
+		span.End()
+	}()
 
-	finalPayload, header, iresp, err := r.ExecuteTriggerInterceptors(t, request, event, log, eventID, extensions)
+	finalPayload, header, iresp, err := r.executeInterceptors(t.Spec.Interceptors, request, event, log, eventID, triggerID(t), t.Namespace, extensions, t.Spec.RetryPolicy, inv)
 	if err != nil {
 		log.Error(err)
+		r.recordTimeout(err)
+		r.recordTriggerFailure(ev, &t, InterceptorFailedReason, err, log)
+		result.ErrorMessage = err.Error()
+		r.addResult(ev, CreationFailedType, result, inv, log)
+		if !ev.dryRun {
+			dl := r.newDeadLetter(eventID, request, event, err)
+			dl.Trigger = fmt.Sprintf("%s/%s", t.Namespace, t.Name)
+			r.sendDeadLetter(t.Spec.RetryPolicy, dl, log)
+		}
 		return
 	}
 
 	if iresp != nil {
 		if !iresp.Continue {
 			log.Infof("interceptor stopped trigger processing: %v", iresp.Status.Err())
+			result.Status = &iresp.Status
+			r.addResult(ev, InterceptorRejectedType, result, inv, log)
 			return
 		}
 	}
+	result.Continue = true
+	r.emitLifecycleEvent(ev, TriggerMatchedType, &result, log)
 
+	_, templateSpan := startSpan(ctx, resolveTemplateSpanName)
 	rt, err := template.ResolveTrigger(t,
 		r.TriggerBindingLister.TriggerBindings(t.Namespace).Get,
 		r.ClusterTriggerBindingLister.Get,
 		r.TriggerTemplateLister.TriggerTemplates(t.Namespace).Get)
 	if err != nil {
+		endSpan(templateSpan, err)
 		log.Error(err)
+		r.recordBindingFailure(ctx)
+		r.recordTriggerFailure(ev, &t, TriggerResolutionFailedReason, err, log)
+		result.ErrorMessage = err.Error()
+		r.addResult(ev, CreationFailedType, result, inv, log)
 		return
 	}
 	if iresp != nil && iresp.Extensions != nil {
 		extensions = iresp.Extensions
 	}
-	params, err := template.ResolveParams(rt, finalPayload, header, extensions)
+	params, err := template.ResolveParams(rt, finalPayload, header, extensions, template.EventContext{ClientIP: r.clientIP(request)})
 	if err != nil {
+		endSpan(templateSpan, err)
 		log.Error(err)
+		r.recordBindingFailure(ctx)
+		r.recordTriggerFailure(ev, &t, ParamsResolutionFailedReason, err, log)
+		result.ErrorMessage = err.Error()
+		r.addResult(ev, CreationFailedType, result, inv, log)
 		return
 	}
 
 	log.Infof("ResolvedParams : %+v", params)
+	inv.params = params
 	resources := template.ResolveResources(rt.TriggerTemplate, params)
+	templateSpan.SetAttributes(attribute.Int64("resources", int64(len(resources))))
+	templateSpan.End()
+	if ev.dryRun {
+		result.RenderedResources = resources
+		ev.addResult(result)
+		return
+	}
 
-	if err := r.CreateResources(t.Namespace, t.Spec.ServiceAccountName, resources, t.Name, eventID, log); err != nil {
+	var group *concurrencyGroup
+	if t.Spec.Concurrency != nil && r.ConcurrencyGroups != nil {
+		group = r.concurrencyGroup(t, finalPayload, header, extensions, resources, log)
+	}
+	var groupClient dynamic.Interface
+	if group != nil {
+		unlock := r.ConcurrencyGroups.lock(group.id)
+		defer unlock()
+		var create bool
+		groupClient, err = r.dynamicClientFor(t.Spec.ServiceAccountName, t.Namespace, log)
+		if err == nil {
+			resources, create, err = r.ConcurrencyGroups.prepare(ctx, group, groupClient, resources)
+		}
+		if err != nil {
+			log.Error(err)
+			r.recordTriggerFailure(ev, &t, ResourceCreationFailedReason, err, log)
+			result.ErrorMessage = err.Error()
+			r.addResult(ev, CreationFailedType, result, inv, log)
+			return
+		}
+		if !create {
+			log.Infof("skipping event, runs of its concurrency group %s are in progress", group.id)
+			result.Skipped = true
+			r.addResult(ev, ResourcesSkippedType, result, inv, log)
+			return
+		}
+	}
+
+	created, err := r.CreateResources(ctx, t.Namespace, t.Spec.ServiceAccountName, resources, t.Name, eventID, log, t.Spec.RetryPolicy)
+	result.Resources = toCreatedResources(created)
+	if group != nil && err == nil {
+		r.ConcurrencyGroups.finish(ctx, group, groupClient, created, log)
+	}
+	if err != nil {
 		log.Error(err)
+		r.recordTimeout(err)
+		r.recordTriggerFailure(ev, &t, ResourceCreationFailedReason, err, log)
+		rb := rollback{kept: created, remaining: resources[len(created):]}
+		if len(created) > 0 && metav1.HasAnnotation(rt.TriggerTemplate.ObjectMeta, template.AtomicAnnotation) {
+			rb = r.rollbackResources(t.Namespace, t.Spec.ServiceAccountName, eventID, resources, created, log)
+			result.Resources = toCreatedResources(rb.kept)
+			result.RolledBack = toCreatedResources(rb.deleted)
+		}
+		result.ErrorMessage = err.Error()
+		r.addResult(ev, CreationFailedType, result, inv, log)
+		dl := r.newDeadLetter(eventID, request, event, err)
+		dl.Trigger = fmt.Sprintf("%s/%s", t.Namespace, t.Name)
+		dl.Resources = rb.remaining
+		r.sendDeadLetter(t.Spec.RetryPolicy, dl, log)
 		return
 	}
+	r.addResult(ev, ResourcesCreatedType, result, inv, log)
 	go r.recordResourceCreation(resources)
 }
 
 func (r Sink) ExecuteTriggerInterceptors(t triggersv1.Trigger, in *http.Request, event []byte, log *zap.SugaredLogger, eventID string, extensions map[string]interface{}) ([]byte, http.Header, *triggersv1.InterceptorResponse, error) {
-	return r.ExecuteInterceptors(t.Spec.Interceptors, in, event, log, eventID, fmt.Sprintf("namespaces/%s/triggers/%s", t.Namespace, t.Name), t.Namespace, extensions)
+	return r.ExecuteInterceptors(t.Spec.Interceptors, in, event, log, eventID, triggerID(t), t.Namespace, extensions, t.Spec.RetryPolicy)
+}
+
+// triggerID returns the ID that identifies t to interceptors.
+func triggerID(t triggersv1.Trigger) string {
+	return fmt.Sprintf("namespaces/%s/triggers/%s", t.Namespace, t.Name)
 }
 
 // ExecuteInterceptor executes all interceptors for the Trigger and returns back the body, header, and InterceptorResponse to use.
+// Interceptors are called with the context of the request, and each ClusterInterceptor call is bounded by the timeout of the
+// ClusterInterceptor. Failed ClusterInterceptor calls are retried according to retryPolicy.
 // When TEP-0022 is fully implemented, this function will only return the InterceptorResponse and error.
-func (r Sink) ExecuteInterceptors(trInt []*triggersv1.TriggerInterceptor, in *http.Request, event []byte, log *zap.SugaredLogger, eventID string, triggerID string, namespace string, extensions map[string]interface{}) ([]byte, http.Header, *triggersv1.InterceptorResponse, error) {
+func (r Sink) ExecuteInterceptors(trInt []*triggersv1.TriggerInterceptor, in *http.Request, event []byte, log *zap.SugaredLogger, eventID string, triggerID string, namespace string, extensions map[string]interface{}, retryPolicy *triggersv1.RetryPolicy) ([]byte, http.Header, *triggersv1.InterceptorResponse, error) {
+	return r.executeInterceptors(trInt, in, event, log, eventID, triggerID, namespace, extensions, retryPolicy, nil)
+}
+
+// executeInterceptors executes the interceptors like ExecuteInterceptors, and records
+// the result of each interceptor in inv, if it is not nil.
+func (r Sink) executeInterceptors(trInt []*triggersv1.TriggerInterceptor, in *http.Request, event []byte, log *zap.SugaredLogger, eventID string, triggerID string, namespace string, extensions map[string]interface{}, retryPolicy *triggersv1.RetryPolicy, inv *invocation) ([]byte, http.Header, *triggersv1.InterceptorResponse, error) {
 	if len(trInt) == 0 {
 		return event, in.Header, nil, nil
 	}
+	ctx := in.Context()
 
 	// request is the request sent to the interceptors in the chain. Each interceptor can set the InterceptorParams field
 	// or add to the Extensions
@@ -351,33 +926,55 @@ func (r Sink) ExecuteInterceptors(trInt []*triggersv1.TriggerInterceptor, in *ht
 			EventURL: in.URL.String(),
 			EventID:  eventID,
 			// t.Name might not be fully accurate until we get rid of triggers inlined within EventListener
-			TriggerID: triggerID,
+			TriggerID:     triggerID,
+			ClientSubject: clientSubject(in),
+			ClientIP:      r.clientIP(in),
 		},
 	}
 
 	for _, i := range trInt {
+		start := time.Now()
+		ictx, span := startSpan(ctx, interceptorSpanName, trace.WithSpanKind(trace.SpanKindClient))
+		span.SetAttributes(attribute.String("interceptor", interceptorName(i)))
+		finish := func(resp *triggersv1.InterceptorResponse, err error) {
+			inv.recordInterceptor(interceptorName(i), start, resp, err)
+			r.recordInterceptorMetrics(ctx, interceptorMetricName(i), interceptorCode(resp, err), time.Since(start))
+			endSpan(span, err)
+		}
 		if i.Webhook != nil { // Old style interceptor
 			body, err := extendBodyWithExtensions([]byte(request.Body), request.Extensions)
 			if err != nil {
-				return nil, nil, nil, fmt.Errorf("could not merge extensions with body: %w", err)
+				err = fmt.Errorf("could not merge extensions with body: %w", err)
+				finish(nil, err)
+				return nil, nil, nil, err
 			}
-			req := &http.Request{
+			// Webhook interceptors cannot set a timeout, so a hung webhook is bounded by the default.
+			callCtx, cancel := withTimeout(ictx, interceptorTimeoutScope, interceptorName(i), DefaultInterceptorTimeout)
+			req := (&http.Request{
 				Method: http.MethodPost,
 				Header: request.Header,
 				URL:    in.URL,
 				Body:   ioutil.NopCloser(bytes.NewBuffer(body)),
-			}
+			}).WithContext(callCtx)
 			interceptor := webhook.NewInterceptor(i.Webhook, r.HTTPClient, namespace, log)
 			res, err := interceptor.ExecuteTrigger(req)
 			if err != nil {
+				cancel()
+				err = timeoutError(callCtx, err)
+				finish(nil, err)
 				return nil, nil, nil, err
 			}
 
 			payload, err := ioutil.ReadAll(res.Body)
+			res.Body.Close()
 			if err != nil {
-				return nil, nil, nil, fmt.Errorf("error reading webhook interceptor response body: %w", err)
+				err = timeoutError(callCtx, fmt.Errorf("error reading webhook interceptor response body: %w", err))
+				cancel()
+				finish(nil, err)
+				return nil, nil, nil, err
 			}
-			defer res.Body.Close()
+			cancel()
+			finish(nil, nil)
 			// Set the next request to be the output of the last response to enable
 			// request chaining.
 			request.Header = res.Header.Clone()
@@ -387,11 +984,31 @@ func (r Sink) ExecuteInterceptors(trInt []*triggersv1.TriggerInterceptor, in *ht
 		request.InterceptorParams = interceptors.GetInterceptorParams(i)
 		url, err := interceptors.ResolveToURL(r.ClusterInterceptorLister.Get, i.GetName())
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("could not resolve interceptor URL: %w", err)
+			err = fmt.Errorf("could not resolve interceptor URL: %w", err)
+			finish(nil, err)
+			return nil, nil, nil, err
 		}
 
-		// TODO: Plumb through context from EL
-		interceptorResponse, err := interceptors.Execute(context.Background(), r.HTTPClient, &request, url.String())
+		timeout := r.interceptorTimeout(i.GetName())
+		var interceptorResponse *triggersv1.InterceptorResponse
+		// Calls are not retried once the trigger or event they are made for timed out.
+		retryable := func(err error) bool {
+			return ctx.Err() == nil && isRetryableInterceptorError(err)
+		}
+		err = retry.OnError(retryBackoff(retryPolicy), retryable, func() error {
+			callCtx, cancel := withTimeout(ictx, interceptorTimeoutScope, i.GetName(), timeout)
+			defer cancel()
+			var err error
+			interceptorResponse, err = interceptors.Execute(callCtx, r.HTTPClient, &request, url.String())
+			if err != nil {
+				err = timeoutError(callCtx, err)
+				if retryable(err) {
+					log.Warnf("interceptor %s failed: %v", i.GetName(), err)
+				}
+			}
+			return err
+		})
+		finish(interceptorResponse, err)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -414,30 +1031,109 @@ func (r Sink) ExecuteInterceptors(trInt []*triggersv1.TriggerInterceptor, in *ht
 	}, nil
 }
 
-func (r Sink) CreateResources(triggerNS, sa string, res []json.RawMessage, triggerName, eventID string, log *zap.SugaredLogger) error {
-	discoveryClient := r.DiscoveryClient
-	dynamicClient := r.DynamicClient
-	var err error
-	if len(sa) > 0 {
-		// So at start up the discovery and dynamic clients are created using the in cluster config
-		// of this pod (i.e. using the credentials of the serviceaccount associated with the EventListener)
+// interceptorMetricName returns the name of the interceptor i in metrics. Webhook
+// interceptors are not named, and their URLs would add a time series per webhook.
+func interceptorMetricName(i *triggersv1.TriggerInterceptor) string {
+	if i.Webhook != nil {
+		return "webhook"
+	}
+	return i.GetName()
+}
+
+// interceptorCode returns the code of the outcome of an interceptor call.
+func interceptorCode(resp *triggersv1.InterceptorResponse, err error) codes.Code {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case err != nil:
+		return codes.Unknown
+	case resp != nil && !resp.Continue:
+		return resp.Status.Code
+	}
+	return codes.OK
+}
+
+// CreateResources creates the resources resolved from a TriggerTemplate and returns
+// the resources that were created. Creation failing with a transient error is retried
+// according to retryPolicy until ctx is done. If creating a resource fails, the resources
+// created before it are returned along with the error.
+func (r Sink) CreateResources(ctx context.Context, triggerNS, sa string, res []json.RawMessage, triggerName, eventID string, log *zap.SugaredLogger, retryPolicy *triggersv1.RetryPolicy) ([]*unstructured.Unstructured, error) {
+	dynamicClient, err := r.dynamicClientFor(sa, triggerNS, log)
+	if err != nil {
+		return nil, err
+	}
 
-		// However, we also have a ServiceAccountName reference with each EventListenerTrigger to allow
-		// for more fine grained authorization control around the resources we create below.
-		discoveryClient, dynamicClient, err = r.Auth.OverrideAuthentication(sa, triggerNS, log, r.DiscoveryClient, r.DynamicClient)
+	var created []*unstructured.Unstructured
+	for _, rr := range res {
+		// Resources refer to the resources created before them, such as to own them.
+		rr, err := template.ResolveResourceReferences(rr, created)
 		if err != nil {
-			log.Errorf("problem cloning rest config: %#v", err)
+			log.Errorf("problem resolving resource references: %v", err)
+			return created, err
+		}
+		var obj *unstructured.Unstructured
+		retryable := func(err error) bool {
+			return ctx.Err() == nil && isRetryableCreateError(err)
+		}
+		err = retry.OnError(retryBackoff(retryPolicy), retryable, func() error {
+			var err error
+			obj, err = resources.Create(ctx, r.Logger, rr, triggerName, eventID, r.EventListenerName, triggerNS, r.RESTMapper, dynamicClient)
+			if err != nil && retryable(err) {
+				log.Warnf("problem creating obj: %v", err)
+			}
 			return err
+		})
+		r.recordCreateMetrics(ctx, rr, err)
+		if obj != nil {
+			created = append(created, obj)
+		}
+		if err != nil {
+			if len(sa) > 0 && kerrors.IsUnauthorized(err) {
+				// The credentials of the client were rejected, so it is not reused.
+				r.Auth.Invalidate(sa, triggerNS)
+			}
+			log.Errorf("problem creating obj: %#v", err)
+			return created, timeoutError(ctx, err)
 		}
 	}
+	return created, nil
+}
 
-	for _, rr := range res {
-		if err := resources.Create(r.Logger, rr, triggerName, eventID, r.EventListenerName, triggerNS, discoveryClient, dynamicClient); err != nil {
-			log.Errorf("problem creating obj: %#v", err)
-			return err
+// dynamicClientFor returns the client that writes the resources of Triggers with the
+// service account sa in namespace.
+func (r Sink) dynamicClientFor(sa, namespace string, log *zap.SugaredLogger) (dynamic.Interface, error) {
+	if len(sa) == 0 {
+		return r.DynamicClient, nil
+	}
+	// So at start up the dynamic client is created using the in cluster config
+	// of this pod (i.e. using the credentials of the serviceaccount associated with the EventListener)
+
+	// However, we also have a ServiceAccountName reference with each EventListenerTrigger to allow
+	// for more fine grained authorization control around the resources we create below.
+	dynamicClient, err := r.Auth.OverrideAuthentication(sa, namespace, log, r.DynamicClient)
+	if err != nil {
+		log.Errorf("problem cloning rest config: %#v", err)
+		return nil, err
+	}
+	return dynamicClient, nil
+}
+
+// toCreatedResources returns references to the given resources.
+func toCreatedResources(objs []*unstructured.Unstructured) []CreatedResource {
+	var refs []CreatedResource
+	for _, obj := range objs {
+		if obj == nil {
+			continue
 		}
+		refs = append(refs, CreatedResource{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			UID:        string(obj.GetUID()),
+		})
 	}
-	return nil
+	return refs
 }
 
 // extendBodyWithExtensions merges the extensions into the given body.
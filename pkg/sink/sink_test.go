@@ -18,7 +18,9 @@ package sink
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -32,6 +34,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/gorilla/mux"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
 	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	dynamicclientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
@@ -44,16 +47,20 @@ import (
 	triggertemplateinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1beta1/triggertemplate"
 	"github.com/tektoncd/triggers/pkg/interceptors"
 	"github.com/tektoncd/triggers/pkg/interceptors/server"
+	"github.com/tektoncd/triggers/pkg/resources"
 	"github.com/tektoncd/triggers/pkg/template"
 	"github.com/tektoncd/triggers/test"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/codes"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
@@ -141,13 +148,14 @@ func getSinkAssets(t *testing.T, res test.Resources, elName string, webhookInter
 		EventListenerName:           elName,
 		EventListenerNamespace:      namespace,
 		DynamicClient:               dynamicSet,
-		DiscoveryClient:             clients.Kube.Discovery(),
+		RESTMapper:                  resources.NewRESTMapper(clients.Kube.Discovery()),
 		KubeClientSet:               clients.Kube,
 		TriggersClient:              clients.Triggers,
 		HTTPClient:                  httpClient,
 		Logger:                      logger.Sugar(),
 		Auth:                        DefaultAuthOverride{},
 		WGProcessTriggers:           &sync.WaitGroup{},
+		Deduplicator:                NewDeduplicator(clients.Kube, elName, namespace, logger.Sugar()),
 		Recorder:                    recorder,
 		EventListenerLister:         eventlistenerinformer.Get(ctx).Lister(),
 		TriggerLister:               triggerinformer.Get(ctx).Lister(),
@@ -325,11 +333,13 @@ func TestHandleEvent(t *testing.T) {
 				Name:      "git-clone-run",
 				Namespace: namespace,
 				Labels: map[string]string{
-					"app":                                  "bar\t\r\nbaz昨",
-					"type":                                 "application/json",
-					"triggers.tekton.dev/eventlistener":    eventListenerName,
-					"triggers.tekton.dev/trigger":          "git-clone-trigger",
-					"triggers.tekton.dev/triggers-eventid": "12345",
+					"app":                                   "bar\t\r\nbaz昨",
+					"type":                                  "application/json",
+					"triggers.tekton.dev/eventlistener":     eventListenerName,
+					"triggers.tekton.dev/trigger":           "git-clone-trigger",
+					"triggers.tekton.dev/triggers-eventid":  "12345",
+					"triggers.tekton.dev/trigger-namespace": namespace,
+					"triggers.tekton.dev/created-by-event":  "true",
 				},
 			},
 			Spec: pipelinev1.TaskRunSpec{
@@ -374,6 +384,10 @@ func TestHandleEvent(t *testing.T) {
 		tenGitCloneTaskRuns = append(tenGitCloneTaskRuns, *tr)
 	}
 
+	// barGitCloneTaskRun is gitCloneTaskRun created for a Trigger in the bar namespace
+	barGitCloneTaskRun := gitCloneTaskRun.DeepCopy()
+	barGitCloneTaskRun.Labels["triggers.tekton.dev/trigger-namespace"] = "bar"
+
 	tests := []struct {
 		name string
 		// resources are the K8s objects to setup the test env.
@@ -465,7 +479,7 @@ func TestHandleEvent(t *testing.T) {
 			}},
 		},
 		eventBody: eventBody,
-		want:      []pipelinev1.TaskRun{gitCloneTaskRun},
+		want:      []pipelinev1.TaskRun{*barGitCloneTaskRun},
 	}, {
 		name: "label selector match expressions",
 		resources: test.Resources{
@@ -539,7 +553,7 @@ func TestHandleEvent(t *testing.T) {
 		},
 		eventBody: eventBody,
 		// only one of the tasks is invoked
-		want: []pipelinev1.TaskRun{gitCloneTaskRun},
+		want: []pipelinev1.TaskRun{*barGitCloneTaskRun},
 	}, {
 		name: "label selector match expressions without namespace selector",
 		resources: test.Resources{
@@ -870,11 +884,13 @@ func TestHandleEvent(t *testing.T) {
 				Name:      "name-from-webhook",
 				Namespace: namespace,
 				Labels: map[string]string{
-					"app":                                  "triggers",
-					"type":                                 "bar",
-					"triggers.tekton.dev/eventlistener":    eventListenerName,
-					"triggers.tekton.dev/trigger":          "git-clone-trigger",
-					"triggers.tekton.dev/triggers-eventid": "12345",
+					"app":                                   "triggers",
+					"type":                                  "bar",
+					"triggers.tekton.dev/eventlistener":     eventListenerName,
+					"triggers.tekton.dev/trigger":           "git-clone-trigger",
+					"triggers.tekton.dev/triggers-eventid":  "12345",
+					"triggers.tekton.dev/trigger-namespace": namespace,
+					"triggers.tekton.dev/created-by-event":  "true",
 				},
 			},
 			Spec: pipelinev1.TaskRunSpec{
@@ -1047,6 +1063,65 @@ func TestHandleEvent_Error(t *testing.T) {
 	}
 }
 
+func TestHandleEvent_QueueFull(t *testing.T) {
+	elName := "test-el"
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name: "first",
+					Template: &triggersv1beta1.EventListenerTemplate{
+						Ref: ptr.String("unknown"),
+					},
+				}, {
+					Name: "second",
+					Template: &triggersv1beta1.EventListenerTemplate{
+						Ref: ptr.String("unknown"),
+					},
+				}},
+			},
+		}},
+	}
+	sink, _ := getSinkAssets(t, resources, elName, nil)
+	// The pool is not started and only has room for one trigger.
+	sink.WorkerPool = NewWorkerPool(1, 1)
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("error making request to eventListener: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Status code mismatch: got %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if got := resp.Header.Get("Retry-After"); got != retryAfterSeconds {
+		t.Errorf("Retry-After header mismatch: got %q, want %q", got, retryAfterSeconds)
+	}
+	var body Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	if body.ErrorMessage != ErrQueueFull.Error() {
+		t.Errorf("ErrorMessage mismatch: got %q, want %q", body.ErrorMessage, ErrQueueFull.Error())
+	}
+	if body.EventID == "" {
+		t.Error("expected the response to have the ID of the rejected event")
+	}
+	if got := len(sink.WorkerPool.queue); got != 0 {
+		t.Errorf("expected no queued triggers, got %d", got)
+	}
+	// Nothing should be left to process.
+	sink.WGProcessTriggers.Wait()
+}
+
 // sequentialInterceptor is a HTTP server that will return sequential responses.
 // It expects a request of the form `{"i": n}`.
 // The response body will always return with the next value set, whereas the
@@ -1400,3 +1475,501 @@ func TestExtendBodyWithExtensions(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleEvent_SyncResponse(t *testing.T) {
+	var (
+		eventBody = json.RawMessage(`{"head_commit": {"id": "testrevision"}, "repository": {"url": "testurl"}}`)
+		elName    = "sync-el"
+		ttSpec    = triggersv1beta1.TriggerTemplateSpec{
+			Params: []triggersv1beta1.ParamSpec{
+				{Name: "url"},
+				{Name: "revision"},
+				{Name: "name", Default: ptr.String("git-clone-run")},
+				{Name: "app", Default: ptr.String("triggers")},
+				{Name: "type", Default: ptr.String("bar")},
+			},
+			ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+				RawExtension: trResourceTemplate(t),
+			}},
+		}
+		bindings = []*triggersv1beta1.EventListenerBinding{
+			{Name: "url", Value: ptr.String("$(body.repository.url)")},
+			{Name: "revision", Value: ptr.String("$(body.head_commit.id)")},
+		}
+		resources = func(annotations map[string]string) test.Resources {
+			return test.Resources{
+				EventListeners: []*triggersv1beta1.EventListener{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        elName,
+						Namespace:   namespace,
+						UID:         types.UID(elUID),
+						Annotations: annotations,
+					},
+					Spec: triggersv1beta1.EventListenerSpec{
+						Triggers: []triggersv1beta1.EventListenerTrigger{{
+							Name:     "create-trigger",
+							Bindings: bindings,
+							Template: &triggersv1beta1.EventListenerTemplate{Spec: &ttSpec},
+						}, {
+							Name: "filtered-trigger",
+							Interceptors: []*triggersv1beta1.EventInterceptor{{
+								Ref: triggersv1beta1.InterceptorRef{Name: "cel"},
+								Params: []triggersv1beta1.InterceptorParams{{
+									Name:  "filter",
+									Value: test.ToV1JSON(t, "has(body.pull_request)"),
+								}},
+							}},
+							Bindings: bindings,
+							Template: &triggersv1beta1.EventListenerTemplate{Spec: &ttSpec},
+						}},
+					},
+				}},
+				ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{cel},
+			}
+		}
+		wantTriggers = []TriggerResult{{
+			Name:      "create-trigger",
+			Namespace: namespace,
+			Continue:  true,
+			Resources: []CreatedResource{{
+				APIVersion: "tekton.dev/v1beta1",
+				Kind:       "TaskRun",
+				Namespace:  namespace,
+				Name:       "git-clone-run",
+			}},
+		}, {
+			Name:      "filtered-trigger",
+			Namespace: namespace,
+			Status: &triggersv1beta1.Status{
+				Code:    codes.FailedPrecondition,
+				Message: "expression has(body.pull_request) did not return true",
+			},
+		}}
+	)
+
+	for _, tc := range []struct {
+		name      string
+		resources test.Resources
+		headers   map[string]string
+		want      []TriggerResult
+	}{{
+		name:      "async by default",
+		resources: resources(nil),
+	}, {
+		name:      "sync response requested by header",
+		resources: resources(nil),
+		headers:   map[string]string{SyncResponseHeader: "true"},
+		want:      wantTriggers,
+	}, {
+		name:      "sync response enabled by annotation",
+		resources: resources(map[string]string{triggers.SyncResponseAnnotation: "true"}),
+		want:      wantTriggers,
+	}, {
+		name:      "annotation disables sync response",
+		resources: resources(map[string]string{triggers.SyncResponseAnnotation: "false"}),
+		headers:   map[string]string{SyncResponseHeader: "true"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, _ := getSinkAssets(t, tc.resources, elName, nil)
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			req, err := http.NewRequest("POST", ts.URL, bytes.NewReader(eventBody))
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error sending request: %s", err)
+			}
+			defer resp.Body.Close()
+			sink.WGProcessTriggers.Wait()
+
+			wantStatus := http.StatusAccepted
+			if tc.want != nil {
+				wantStatus = http.StatusOK
+			}
+			if resp.StatusCode != wantStatus {
+				t.Fatalf("expected response code %d but got: %v", wantStatus, resp.Status)
+			}
+			var got Response
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Error reading response body: %s", err)
+			}
+			compareResults := func(x, y TriggerResult) bool {
+				return x.Name < y.Name
+			}
+			if diff := cmp.Diff(tc.want, got.Triggers, cmpopts.SortSlices(compareResults)); diff != "" {
+				t.Errorf("did not get expected trigger results -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_Duplicate(t *testing.T) {
+	elName := "dedup-el"
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Deduplication: &triggersv1beta1.Deduplication{
+					Key: "$(header.X-GitHub-Delivery)",
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name: "create-trigger",
+					Bindings: []*triggersv1beta1.EventListenerBinding{
+						{Name: "name", Value: ptr.String("run-$(header.X-GitHub-Delivery)")},
+					},
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: &triggersv1beta1.TriggerTemplateSpec{
+						Params: []triggersv1beta1.ParamSpec{
+							{Name: "name"},
+							{Name: "url", Default: ptr.String("testurl")},
+							{Name: "revision", Default: ptr.String("testrevision")},
+							{Name: "app", Default: ptr.String("triggers")},
+							{Name: "type", Default: ptr.String("bar")},
+						},
+						ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+							RawExtension: trResourceTemplate(t),
+						}},
+					}},
+				}},
+			},
+		}},
+	}
+	sink, dynamicClient := getSinkAssets(t, resources, elName, nil)
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		delivery      string
+		wantStatus    int
+		wantDuplicate bool
+	}{{
+		delivery:   "1",
+		wantStatus: http.StatusAccepted,
+	}, {
+		delivery:      "1",
+		wantStatus:    http.StatusOK,
+		wantDuplicate: true,
+	}, {
+		delivery:   "2",
+		wantStatus: http.StatusAccepted,
+	}} {
+		req, err := http.NewRequest("POST", ts.URL, bytes.NewReader([]byte(`{}`)))
+		if err != nil {
+			t.Fatalf("error creating request: %s", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Delivery", tc.delivery)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("error sending request: %s", err)
+		}
+		sink.WGProcessTriggers.Wait()
+		var got Response
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatalf("Error reading response body: %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.wantStatus {
+			t.Errorf("delivery %s: expected response code %d but got: %v", tc.delivery, tc.wantStatus, resp.Status)
+		}
+		if got.Duplicate != tc.wantDuplicate {
+			t.Errorf("delivery %s: expected duplicate to be %t", tc.delivery, tc.wantDuplicate)
+		}
+	}
+
+	var gotNames []string
+	for _, tr := range toTaskRun(t, dynamicClient.Actions()) {
+		gotNames = append(gotNames, tr.Name)
+	}
+	if diff := cmp.Diff([]string{"run-1", "run-2"}, gotNames); diff != "" {
+		t.Errorf("did not get expected TaskRuns -want,+got: %s", diff)
+	}
+}
+
+func TestHandleEvent_RedeliveryAfterFailure(t *testing.T) {
+	elName := "dedup-el"
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Deduplication: &triggersv1beta1.Deduplication{
+					Key: "$(header.X-GitHub-Delivery)",
+				},
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name: "create-trigger",
+					Bindings: []*triggersv1beta1.EventListenerBinding{
+						{Name: "name", Value: ptr.String("run-$(header.X-GitHub-Delivery)")},
+					},
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: &triggersv1beta1.TriggerTemplateSpec{
+						Params: []triggersv1beta1.ParamSpec{
+							{Name: "name"},
+							{Name: "url", Default: ptr.String("testurl")},
+							{Name: "revision", Default: ptr.String("testrevision")},
+							{Name: "app", Default: ptr.String("triggers")},
+							{Name: "type", Default: ptr.String("bar")},
+						},
+						ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+							RawExtension: trResourceTemplate(t),
+						}},
+					}},
+				}},
+			},
+		}},
+	}
+	sink, dynamicClient := getSinkAssets(t, resources, elName, nil)
+	failed := false
+	dynamicClient.PrependReactor("create", "taskruns", func(ktesting.Action) (bool, runtime.Object, error) {
+		if !failed {
+			failed = true
+			return true, nil, kerrors.NewForbidden(schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}, "run-1", errors.New("forbidden"))
+		}
+		return false, nil, nil
+	})
+
+	// The creation of the resources of the first delivery fails, and the event is
+	// processed again when it is redelivered.
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Delivery", "1")
+		resp := httptest.NewRecorder()
+		sink.HandleEvent(resp, req)
+		sink.WGProcessTriggers.Wait()
+
+		var got Response
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatalf("Error reading response body: %s", err)
+		}
+		if got.Duplicate {
+			t.Fatalf("delivery %d was dropped as a duplicate", i)
+		}
+	}
+
+	if _, err := dynamicClient.Resource(taskRunsGVR).Namespace(namespace).Get(context.Background(), "run-1", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the redelivered event to create its TaskRun: %v", err)
+	}
+}
+
+func TestHandleEvent_Path(t *testing.T) {
+	elName := "path-el"
+	trigger := func(name, path string, pathType triggersv1beta1.PathType) triggersv1beta1.EventListenerTrigger {
+		return triggersv1beta1.EventListenerTrigger{
+			Name:     name,
+			Path:     path,
+			PathType: pathType,
+			Bindings: []*triggersv1beta1.EventListenerBinding{
+				{Name: "name", Value: ptr.String(name + "-run")},
+			},
+			Template: &triggersv1beta1.EventListenerTemplate{Spec: &triggersv1beta1.TriggerTemplateSpec{
+				Params: []triggersv1beta1.ParamSpec{
+					{Name: "name"},
+					{Name: "url", Default: ptr.String("testurl")},
+					{Name: "revision", Default: ptr.String("testrevision")},
+					{Name: "app", Default: ptr.String("triggers")},
+					{Name: "type", Default: ptr.String("bar")},
+				},
+				ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+					RawExtension: trResourceTemplate(t),
+				}},
+			}},
+		}
+	}
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{
+					trigger("github", "/github", triggersv1beta1.PathTypePrefix),
+					trigger("gitlab", "/gitlab", triggersv1beta1.PathTypeExact),
+				},
+			},
+		}},
+	}
+
+	for _, tc := range []struct {
+		path       string
+		wantStatus int
+		wantRuns   []string
+	}{{
+		path:       "/github/push",
+		wantStatus: http.StatusAccepted,
+		wantRuns:   []string{"github-run"},
+	}, {
+		path:       "/gitlab",
+		wantStatus: http.StatusAccepted,
+		wantRuns:   []string{"gitlab-run"},
+	}, {
+		path:       "/cron",
+		wantStatus: http.StatusNotFound,
+	}} {
+		t.Run(tc.path, func(t *testing.T) {
+			sink, dynamicClient := getSinkAssets(t, resources, elName, nil)
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL+tc.path, "application/json", bytes.NewReader([]byte(`{}`)))
+			if err != nil {
+				t.Fatalf("error sending request: %s", err)
+			}
+			defer resp.Body.Close()
+			sink.WGProcessTriggers.Wait()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected response code %d but got: %v", tc.wantStatus, resp.Status)
+			}
+			if resp.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("expected a JSON response, got Content-Type %q", resp.Header.Get("Content-Type"))
+			}
+			var gotNames []string
+			for _, tr := range toTaskRun(t, dynamicClient.Actions()) {
+				gotNames = append(gotNames, tr.Name)
+			}
+			if diff := cmp.Diff(tc.wantRuns, gotNames); diff != "" {
+				t.Errorf("did not get expected TaskRuns -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_CloudEvent(t *testing.T) {
+	elName := "cloudevent-el"
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name: "cloudevent",
+					Bindings: []*triggersv1beta1.EventListenerBinding{
+						{Name: "name", Value: ptr.String("$(ce.id)")},
+					},
+					Template: &triggersv1beta1.EventListenerTemplate{Spec: &triggersv1beta1.TriggerTemplateSpec{
+						Params: []triggersv1beta1.ParamSpec{
+							{Name: "name"},
+							{Name: "url", Default: ptr.String("testurl")},
+							{Name: "revision", Default: ptr.String("testrevision")},
+							{Name: "app", Default: ptr.String("triggers")},
+							{Name: "type", Default: ptr.String("bar")},
+						},
+						ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+							RawExtension: trResourceTemplate(t),
+						}},
+					}},
+				}},
+			},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		header     http.Header
+		body       string
+		wantStatus int
+		wantRuns   []string
+	}{{
+		name: "binary content mode",
+		header: http.Header{
+			"Content-Type":   {"text/plain"},
+			"Ce-Specversion": {"1.0"},
+			"Ce-Id":          {"binary-run"},
+			"Ce-Source":      {"/example"},
+			"Ce-Type":        {"com.example.push"},
+		},
+		body:       "not JSON",
+		wantStatus: http.StatusAccepted,
+		wantRuns:   []string{"binary-run"},
+	}, {
+		name: "structured content mode",
+		header: http.Header{
+			"Content-Type": {"application/cloudevents+json"},
+		},
+		body:       `{"specversion":"1.0","id":"structured-run","source":"/example","type":"com.example.push","data":{"a":"b"}}`,
+		wantStatus: http.StatusAccepted,
+		wantRuns:   []string{"structured-run"},
+	}, {
+		name: "invalid CloudEvent",
+		header: http.Header{
+			"Content-Type": {"application/cloudevents+json"},
+		},
+		body:       `{"specversion":"1.0","source":"/example","type":"com.example.push"}`,
+		wantStatus: http.StatusBadRequest,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, dynamicClient := getSinkAssets(t, resources, elName, nil)
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(tc.body)))
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			req.Header = tc.header
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error sending request: %s", err)
+			}
+			defer resp.Body.Close()
+			sink.WGProcessTriggers.Wait()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected response code %d but got: %v", tc.wantStatus, resp.Status)
+			}
+			var gotNames []string
+			for _, tr := range toTaskRun(t, dynamicClient.Actions()) {
+				gotNames = append(gotNames, tr.Name)
+			}
+			if diff := cmp.Diff(tc.wantRuns, gotNames); diff != "" {
+				t.Errorf("did not get expected TaskRuns -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateResources_ResourceReferences(t *testing.T) {
+	owner := json.RawMessage(`{"apiVersion":"tekton.dev/v1beta1","kind":"TaskRun","metadata":{"name":"owner","namespace":"foo"}}`)
+	owned := json.RawMessage(`{"apiVersion":"tekton.dev/v1beta1","kind":"TaskRun","metadata":{"name":"owned","namespace":"foo",` +
+		`"ownerReferences":[{"apiVersion":"tekton.dev/v1beta1","kind":"TaskRun","name":"$(resources.0.metadata.name)","uid":"$(resources.0.metadata.uid)"}]}}`)
+
+	s, dynamicClient := getSinkAssets(t, test.Resources{}, "my-el", nil)
+	// The fake client does not set UIDs.
+	dynamicClient.PrependReactor("create", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		obj := action.(ktesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		obj.SetUID(types.UID(obj.GetName() + "-uid"))
+		return false, nil, nil
+	})
+
+	created, err := s.CreateResources(context.Background(), namespace, "", []json.RawMessage{owner, owned}, "my-trigger", eventID, s.Logger, nil)
+	if err != nil {
+		t.Fatalf("CreateResources() unexpected error: %v", err)
+	}
+	want := []metav1.OwnerReference{{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun", Name: "owner", UID: "owner-uid"}}
+	if diff := cmp.Diff(want, created[1].GetOwnerReferences()); diff != "" {
+		t.Errorf("unexpected owner references -want +got: %s", diff)
+	}
+
+	// References to resources that are not created before fail.
+	if _, err := s.CreateResources(context.Background(), namespace, "", []json.RawMessage{owned}, "my-trigger", eventID, s.Logger, nil); err == nil {
+		t.Error("CreateResources() expected error for a reference to a resource that was not created")
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// forwardedForHeader is the header that proxies append the address they received a request from to.
+const forwardedForHeader = "X-Forwarded-For"
+
+// EnforceSourceIPPolicy rejects requests whose client IP is not in one of AllowedSourceRanges
+// with a 403 status, before their body is read. Other requests are passed on to eventHandler.
+func (r Sink) EnforceSourceIPPolicy(eventHandler http.Handler) http.Handler {
+	if len(r.AllowedSourceRanges) == 0 {
+		return eventHandler
+	}
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		clientIP := r.clientIP(request)
+		if !containsIP(r.AllowedSourceRanges, net.ParseIP(clientIP)) {
+			recordSourceIPRejected()
+			r.rejectPayload(response, http.StatusForbidden, fmt.Sprintf("requests from %q are not allowed", clientIP))
+			return
+		}
+		eventHandler.ServeHTTP(response, request)
+	})
+}
+
+// clientIP returns the IP of the client that sent request. If the request was sent
+// through TrustedProxies, each of them appended the address it received the request
+// from to the X-Forwarded-For header, so the header is read from the end, and the
+// first address that is not a trusted proxy is the client. Addresses before it could
+// have been set by the client, and are ignored.
+func (r Sink) clientIP(request *http.Request) string {
+	ip := remoteIP(request.RemoteAddr)
+	if ip == nil {
+		return ""
+	}
+	if containsIP(r.TrustedProxies, ip) {
+		forwarded := strings.Split(strings.Join(request.Header.Values(forwardedForHeader), ","), ",")
+		for i := len(forwarded) - 1; i >= 0; i-- {
+			hop := net.ParseIP(strings.TrimSpace(forwarded[i]))
+			if hop == nil {
+				// The proxy that appended a malformed address cannot be trusted, so
+				// the request is attributed to the last proxy that can.
+				break
+			}
+			ip = hop
+			if !containsIP(r.TrustedProxies, hop) {
+				break
+			}
+		}
+	}
+	return ip.String()
+}
+
+// remoteIP returns the IP of the host:port address addr, or nil if it is not an IP.
+func remoteIP(addr string) net.IP {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return net.ParseIP(host)
+}
+
+// containsIP returns true if ip is in one of ranges.
+func containsIP(ranges []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, r := range ranges {
+		if r.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tektoncd/triggers/test"
+)
+
+func mustParseCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	t.Helper()
+	var ranges []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ranges = append(ranges, ipNet)
+	}
+	return ranges
+}
+
+func TestSink_EnforceSourceIPPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		allowed        []string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   []string
+		wantStatusCode int
+	}{{
+		name:           "no policy",
+		remoteAddr:     "203.0.113.7:52000",
+		wantStatusCode: http.StatusOK,
+	}, {
+		name:           "allowed IP",
+		allowed:        []string{"192.30.252.0/22"},
+		remoteAddr:     "192.30.252.1:52000",
+		wantStatusCode: http.StatusOK,
+	}, {
+		name:           "allowed IPv6",
+		allowed:        []string{"2a0a:a440::/29"},
+		remoteAddr:     "[2a0a:a440::1]:52000",
+		wantStatusCode: http.StatusOK,
+	}, {
+		name:           "IP not allowed",
+		allowed:        []string{"192.30.252.0/22"},
+		remoteAddr:     "203.0.113.7:52000",
+		wantStatusCode: http.StatusForbidden,
+	}, {
+		name:           "X-Forwarded-For of untrusted peer is ignored",
+		allowed:        []string{"192.30.252.0/22"},
+		remoteAddr:     "203.0.113.7:52000",
+		forwardedFor:   []string{"192.30.252.1"},
+		wantStatusCode: http.StatusForbidden,
+	}, {
+		name:           "allowed IP through trusted proxy",
+		allowed:        []string{"192.30.252.0/22"},
+		trustedProxies: []string{"10.0.0.0/8"},
+		remoteAddr:     "10.1.2.3:52000",
+		forwardedFor:   []string{"192.30.252.1"},
+		wantStatusCode: http.StatusOK,
+	}, {
+		name:           "spoofed X-Forwarded-For through trusted proxy",
+		allowed:        []string{"192.30.252.0/22"},
+		trustedProxies: []string{"10.0.0.0/8"},
+		remoteAddr:     "10.1.2.3:52000",
+		forwardedFor:   []string{"192.30.252.1, 203.0.113.7"},
+		wantStatusCode: http.StatusForbidden,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, _ := getSinkAssets(t, test.Resources{}, "test-el", nil)
+			sink.AllowedSourceRanges = mustParseCIDRs(t, tc.allowed...)
+			sink.TrustedProxies = mustParseCIDRs(t, tc.trustedProxies...)
+
+			handlerCalled := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerCalled = true
+			})
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+			req.RemoteAddr = tc.remoteAddr
+			for _, v := range tc.forwardedFor {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			resp := httptest.NewRecorder()
+			sink.EnforceSourceIPPolicy(handler).ServeHTTP(resp, req)
+
+			if resp.Code != tc.wantStatusCode {
+				t.Fatalf("Status code mismatch: got %d, want %d", resp.Code, tc.wantStatusCode)
+			}
+			if handlerCalled != (tc.wantStatusCode == http.StatusOK) {
+				t.Errorf("handler called = %t, want %t", handlerCalled, tc.wantStatusCode == http.StatusOK)
+			}
+			if tc.wantStatusCode == http.StatusForbidden {
+				var body Response
+				if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if body.ErrorMessage == "" {
+					t.Error("expected an error message in the response")
+				}
+			}
+		})
+	}
+}
+
+func TestSink_clientIP(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{{
+		name:       "direct client",
+		remoteAddr: "203.0.113.7:52000",
+		want:       "203.0.113.7",
+	}, {
+		name:         "X-Forwarded-For of untrusted peer",
+		remoteAddr:   "203.0.113.7:52000",
+		forwardedFor: []string{"192.30.252.1"},
+		want:         "203.0.113.7",
+	}, {
+		name:         "single trusted proxy",
+		remoteAddr:   "10.1.2.3:52000",
+		forwardedFor: []string{"192.30.252.1"},
+		want:         "192.30.252.1",
+	}, {
+		name:         "chain of trusted proxies",
+		remoteAddr:   "10.1.2.3:52000",
+		forwardedFor: []string{"198.51.100.1, 192.30.252.1, 10.4.5.6", "10.7.8.9"},
+		want:         "192.30.252.1",
+	}, {
+		name:         "only trusted proxies",
+		remoteAddr:   "10.1.2.3:52000",
+		forwardedFor: []string{"10.4.5.6"},
+		want:         "10.4.5.6",
+	}, {
+		name:         "malformed X-Forwarded-For",
+		remoteAddr:   "10.1.2.3:52000",
+		forwardedFor: []string{"192.30.252.1, unknown"},
+		want:         "10.1.2.3",
+	}, {
+		name:       "trusted proxy without X-Forwarded-For",
+		remoteAddr: "10.1.2.3:52000",
+		want:       "10.1.2.3",
+	}, {
+		name:       "not an IP",
+		remoteAddr: "pipe",
+		want:       "",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := Sink{TrustedProxies: mustParseCIDRs(t, "10.0.0.0/8")}
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, v := range tc.forwardedFor {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			if got := sink.clientIP(req); got != tc.want {
+				t.Errorf("clientIP() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
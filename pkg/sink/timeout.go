@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultInterceptorTimeout bounds each request to a webhook interceptor, and to a
+// ClusterInterceptor that does not set a timeout.
+const DefaultInterceptorTimeout = 10 * time.Second
+
+// The scopes of the timeouts applied while processing an event.
+const (
+	eventTimeoutScope       = "event"
+	triggerTimeoutScope     = "trigger"
+	interceptorTimeoutScope = "interceptor"
+)
+
+// TimeoutError is returned when processing an event took longer than one of the
+// timeouts that apply to it.
+type TimeoutError struct {
+	// Scope is what timed out: the whole event, a trigger, or an interceptor.
+	Scope string
+	// Name is the name of the trigger or interceptor that timed out, if any.
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("%s timed out after %s", e.Scope, e.Timeout)
+	}
+	return fmt.Sprintf("%s %s timed out after %s", e.Scope, e.Name, e.Timeout)
+}
+
+// Unwrap makes TimeoutErrors match context.DeadlineExceeded.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// timeoutsKey is the key of the timeouts applied to a context.
+type timeoutsKey struct{}
+
+// appliedTimeout is a timeout applied to a context, along with the context it applies to.
+type appliedTimeout struct {
+	ctx context.Context
+	err *TimeoutError
+}
+
+// withTimeout returns a context that is done once timeout elapses. If timeout is not
+// positive, the context is only done when it is cancelled or ctx is done.
+func withTimeout(ctx context.Context, scope, name string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	parents, _ := ctx.Value(timeoutsKey{}).([]appliedTimeout)
+	timeouts := make([]appliedTimeout, len(parents), len(parents)+1)
+	copy(timeouts, parents)
+	timeouts = append(timeouts, appliedTimeout{
+		ctx: tctx,
+		err: &TimeoutError{Scope: scope, Name: name, Timeout: timeout},
+	})
+	return context.WithValue(tctx, timeoutsKey{}, timeouts), cancel
+}
+
+// durationOf returns the duration of d, or 0 if d is nil.
+func durationOf(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+// timeoutError returns the TimeoutError of the outermost timeout of ctx that elapsed,
+// or err if none has.
+func timeoutError(ctx context.Context, err error) error {
+	timeouts, _ := ctx.Value(timeoutsKey{}).([]appliedTimeout)
+	for _, t := range timeouts {
+		if errors.Is(t.ctx.Err(), context.DeadlineExceeded) {
+			return t.err
+		}
+	}
+	return err
+}
+
+// interceptorTimeout returns the timeout of each request to the ClusterInterceptor name.
+func (r Sink) interceptorTimeout(name string) time.Duration {
+	ci, err := r.ClusterInterceptorLister.Get(name)
+	if err != nil || ci.Spec.Timeout == nil {
+		return DefaultInterceptorTimeout
+	}
+	return ci.Spec.Timeout.Duration
+}
+
+// recordTimeout records the timeout metric if err is a TimeoutError.
+func (r Sink) recordTimeout(err error) {
+	var te *TimeoutError
+	if errors.As(err, &te) {
+		r.recordTimeoutMetrics(te.Scope)
+	}
+}
+
+// detachedContext carries the values of its parent, but is never done. The triggers
+// of an event are processed with a context detached from the request, which is done
+// as soon as the EventListener responds to it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (time.Time, bool)       { return time.Time{}, false }
+func (c detachedContext) Done() <-chan struct{}             { return nil }
+func (c detachedContext) Err() error                        { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
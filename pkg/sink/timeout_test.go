@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTimeoutError(t *testing.T) {
+	errCancelled := errors.New("cancelled")
+	tests := []struct {
+		name    string
+		event   time.Duration
+		trigger time.Duration
+		want    error
+	}{{
+		name:    "trigger timed out",
+		event:   time.Hour,
+		trigger: time.Millisecond,
+		want:    &TimeoutError{Scope: triggerTimeoutScope, Name: "my-trigger", Timeout: time.Millisecond},
+	}, {
+		name:    "event timed out before trigger",
+		event:   time.Millisecond,
+		trigger: time.Hour,
+		want:    &TimeoutError{Scope: eventTimeoutScope, Timeout: time.Millisecond},
+	}, {
+		name: "no timeouts",
+		want: errCancelled,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			eventCtx, cancel := withTimeout(detachedContext{context.Background()}, eventTimeoutScope, "", tc.event)
+			defer cancel()
+			ctx, cancel := withTimeout(eventCtx, triggerTimeoutScope, "my-trigger", tc.trigger)
+			if tc.event == 0 && tc.trigger == 0 {
+				cancel()
+			}
+			defer cancel()
+			<-ctx.Done()
+
+			got := timeoutError(ctx, errCancelled)
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Errorf("timeoutError() -want,+got: %s", diff)
+			}
+			if _, ok := tc.want.(*TimeoutError); ok && !errors.Is(got, context.DeadlineExceeded) {
+				t.Errorf("expected %v to match context.DeadlineExceeded", got)
+			}
+		})
+	}
+}
+
+func TestDetachedContext(t *testing.T) {
+	type key struct{}
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	ctx := detachedContext{parent}
+	cancel()
+	if ctx.Err() != nil {
+		t.Errorf("detached context is done with its parent: %v", ctx.Err())
+	}
+	if got := ctx.Value(key{}); got != "value" {
+		t.Errorf("detached context has value %v, want %q", got, "value")
+	}
+}
+
+// slowInterceptor is a HTTP server that does not respond until the request is cancelled.
+type slowInterceptor struct {
+	calls int32
+}
+
+func (s *slowInterceptor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&s.calls, 1)
+	// The context of the request is only cancelled once its body is read.
+	if _, err := ioutil.ReadAll(r.Body); err != nil {
+		return
+	}
+	select {
+	case <-r.Context().Done():
+	case <-time.After(5 * time.Second):
+	}
+}
+
+func TestExecuteInterceptors_Timeout(t *testing.T) {
+	slow := &triggersv1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slow",
+		},
+		Spec: triggersv1alpha1.ClusterInterceptorSpec{
+			ClientConfig: triggersv1alpha1.ClientConfig{
+				URL: &apis.URL{
+					Scheme: "http",
+					Host:   "slow",
+					Path:   "/",
+				},
+			},
+			Timeout: &metav1.Duration{Duration: 20 * time.Millisecond},
+		},
+	}
+	trInt := []*triggersv1beta1.TriggerInterceptor{{
+		Ref: triggersv1beta1.InterceptorRef{Name: "slow"},
+	}}
+	policy := &triggersv1beta1.RetryPolicy{
+		Attempts: 1,
+		Backoff:  &metav1.Duration{Duration: time.Millisecond},
+	}
+	u, _ := url.Parse("http://example.com")
+
+	t.Run("interceptor timeout is retried", func(t *testing.T) {
+		interceptor := &slowInterceptor{}
+		s, _ := getSinkAssets(t, test.Resources{ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{slow}}, "my-el", interceptor)
+		_, _, _, err := s.ExecuteInterceptors(trInt, &http.Request{URL: u}, []byte(`{}`), s.Logger, eventID, "trigger", namespace, map[string]interface{}{}, policy)
+		var timeoutErr *TimeoutError
+		if !errors.As(err, &timeoutErr) || timeoutErr.Scope != interceptorTimeoutScope || timeoutErr.Name != "slow" {
+			t.Fatalf("ExecuteInterceptors() expected interceptor timeout, got: %v", err)
+		}
+		if calls := atomic.LoadInt32(&interceptor.calls); calls != 2 {
+			t.Errorf("expected interceptor to be called twice, got %d", calls)
+		}
+	})
+
+	t.Run("trigger timeout is not retried", func(t *testing.T) {
+		interceptor := &slowInterceptor{}
+		noTimeout := slow.DeepCopy()
+		noTimeout.Spec.Timeout = nil
+		s, _ := getSinkAssets(t, test.Resources{ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{noTimeout}}, "my-el", interceptor)
+		ctx, cancel := withTimeout(context.Background(), triggerTimeoutScope, "my-trigger", 20*time.Millisecond)
+		defer cancel()
+		req := (&http.Request{URL: u}).WithContext(ctx)
+		_, _, _, err := s.ExecuteInterceptors(trInt, req, []byte(`{}`), s.Logger, eventID, "trigger", namespace, map[string]interface{}{}, policy)
+		var timeoutErr *TimeoutError
+		if !errors.As(err, &timeoutErr) || timeoutErr.Scope != triggerTimeoutScope {
+			t.Fatalf("ExecuteInterceptors() expected trigger timeout, got: %v", err)
+		}
+		if calls := atomic.LoadInt32(&interceptor.calls); calls != 1 {
+			t.Errorf("expected interceptor to be called once, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer the spans of an event are recorded with.
+const tracerName = "github.com/tektoncd/triggers/pkg/sink"
+
+// The names of the spans recorded while processing an event.
+const (
+	handleEventSpanName         = "HandleEvent"
+	selectTriggersSpanName      = "SelectTriggers"
+	processTriggerSpanName      = "ProcessTrigger"
+	processTriggerGroupSpanName = "ProcessTriggerGroup"
+	interceptorSpanName         = "Interceptor"
+	resolveTemplateSpanName     = "ResolveTemplate"
+)
+
+// traceContext propagates the trace of event sources, in W3C Trace Context traceparent
+// and tracestate headers.
+var traceContext = propagation.TraceContext{}
+
+// startSpan starts a span called name that is a child of the span in ctx, if any.
+// The tracer is looked up for every span, so that spans are recorded by the tracer
+// provider set when the EventListener starts.
+func startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// startEventSpan starts the span of handling request. The span continues the trace of
+// the event source if request carries one.
+func startEventSpan(request *http.Request) (*http.Request, trace.Span) {
+	ctx := traceContext.Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+	ctx, span := startSpan(ctx, handleEventSpanName, trace.WithSpanKind(trace.SpanKindServer))
+	return request.WithContext(ctx), span
+}
+
+// endSpan ends span, and marks it as failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// NewTracerProvider returns a tracer provider that records every span and exports them
+// over OTLP/HTTP to the OpenTelemetry Collector at address, which is left to sample the
+// traces it keeps. The traces are reported as coming from serviceName.
+func NewTracerProvider(ctx context.Context, address, serviceName string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptrace.New(ctx, otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(address),
+		otlptracehttp.WithInsecure(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	), nil
+}
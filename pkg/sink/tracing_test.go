@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/test"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+const (
+	incomingTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	incomingTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanID      = "00f067aa0ba902b7"
+)
+
+// recordSpans records every span until the test ends.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	return rec
+}
+
+// tracedInterceptor records the traceparent header of the requests it receives. It echoes
+// the body of webhook interceptor requests, and lets ClusterInterceptor requests continue.
+type tracedInterceptor struct {
+	mu           sync.Mutex
+	traceParents []string
+}
+
+func (i *tracedInterceptor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	i.traceParents = append(i.traceParents, r.Header.Get("traceparent"))
+	i.mu.Unlock()
+	if r.Header.Get("EventListener-Request-URL") != "" {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(&triggersv1beta1.InterceptorResponse{Continue: true})
+}
+
+func TestHandleEvent_Tracing(t *testing.T) {
+	spans := recordSpans(t)
+	elName := "traced-el"
+	resources := test.Resources{
+		EventListeners: []*triggersv1beta1.EventListener{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      elName,
+				Namespace: namespace,
+				UID:       types.UID(elUID),
+			},
+			Spec: triggersv1beta1.EventListenerSpec{
+				Triggers: []triggersv1beta1.EventListenerTrigger{{
+					Name: "traced-trigger",
+					Interceptors: []*triggersv1beta1.EventInterceptor{{
+						Webhook: &triggersv1beta1.WebhookInterceptor{
+							URL: &apis.URL{Scheme: "http", Host: "webhook", Path: "/"},
+						},
+					}, {
+						Ref: triggersv1beta1.InterceptorRef{Name: "traced"},
+					}},
+					Bindings: []*triggersv1beta1.EventListenerBinding{
+						{Name: "url", Value: ptr.String("$(body.repository.url)")},
+						{Name: "revision", Value: ptr.String("$(body.head_commit.id)")},
+					},
+					Template: &triggersv1beta1.EventListenerTemplate{
+						Spec: &triggersv1beta1.TriggerTemplateSpec{
+							Params: []triggersv1beta1.ParamSpec{
+								{Name: "url"},
+								{Name: "revision"},
+								{Name: "name", Default: ptr.String("git-clone-run")},
+								{Name: "app", Default: ptr.String("triggers")},
+								{Name: "type", Default: ptr.String("bar")},
+							},
+							ResourceTemplates: []triggersv1beta1.TriggerResourceTemplate{{
+								RawExtension: trResourceTemplate(t),
+							}},
+						},
+					},
+				}},
+			},
+		}},
+		ClusterInterceptors: []*triggersv1alpha1.ClusterInterceptor{{
+			ObjectMeta: metav1.ObjectMeta{Name: "traced"},
+			Spec: triggersv1alpha1.ClusterInterceptorSpec{
+				ClientConfig: triggersv1alpha1.ClientConfig{
+					URL: &apis.URL{Scheme: "http", Host: "traced", Path: "/"},
+				},
+			},
+		}},
+	}
+	interceptor := &tracedInterceptor{}
+	sink, dynamicClient := getSinkAssets(t, resources, elName, interceptor)
+
+	eventBody := []byte(`{"head_commit": {"id": "testrevision"}, "repository": {"url": "testurl"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(eventBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", incomingTraceParent)
+	resp := httptest.NewRecorder()
+	sink.HandleEvent(resp, req)
+	sink.WGProcessTriggers.Wait()
+	if resp.Code != http.StatusAccepted {
+		t.Fatalf("expected response code 202 but got: %d", resp.Code)
+	}
+
+	var names []string
+	spanIDs := map[string]string{}
+	for _, s := range spans.Ended() {
+		if got := s.SpanContext().TraceID().String(); got != incomingTraceID {
+			t.Errorf("span %s has trace ID %s, want %s", s.Name(), got, incomingTraceID)
+		}
+		if s.Name() == handleEventSpanName && s.Parent().SpanID().String() != incomingSpanID {
+			t.Errorf("span %s has parent %s, want %s", s.Name(), s.Parent().SpanID(), incomingSpanID)
+		}
+		names = append(names, s.Name())
+		spanIDs[s.SpanContext().SpanID().String()] = s.Name()
+	}
+	wantNames := []string{
+		handleEventSpanName,
+		selectTriggersSpanName,
+		processTriggerSpanName,
+		interceptorSpanName,
+		interceptorSpanName,
+		resolveTemplateSpanName,
+		"CreateResource",
+	}
+	if diff := cmp.Diff(wantNames, names, cmpopts.SortSlices(func(x, y string) bool { return x < y })); diff != "" {
+		t.Errorf("unexpected spans -want,+got: %s", diff)
+	}
+
+	// Both interceptors continue the trace from the span of their call.
+	interceptor.mu.Lock()
+	defer interceptor.mu.Unlock()
+	if len(interceptor.traceParents) != 2 {
+		t.Fatalf("expected 2 interceptor requests, got %d", len(interceptor.traceParents))
+	}
+	for _, tp := range interceptor.traceParents {
+		ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.HeaderCarrier{"Traceparent": []string{tp}})
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() || sc.TraceID().String() != incomingTraceID || spanIDs[sc.SpanID().String()] != interceptorSpanName {
+			t.Errorf("interceptor request has unexpected traceparent %q", tp)
+		}
+	}
+
+	trs := toTaskRun(t, dynamicClient.Actions())
+	if len(trs) != 1 {
+		t.Fatalf("expected 1 TaskRun, got %d", len(trs))
+	}
+	if got := trs[0].Annotations[triggers.TraceIDAnnotation]; got != incomingTraceID {
+		t.Errorf("TaskRun has trace ID annotation %q, want %q", got, incomingTraceID)
+	}
+}
+
+// collector is an OpenTelemetry Collector stand-in that receives traces over OTLP/HTTP.
+type collector struct {
+	mu       sync.Mutex
+	services []string
+	spans    []string
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &coltracepb.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.GetResourceSpans() {
+		for _, attr := range rs.GetResource().GetAttributes() {
+			if attr.GetKey() == "service.name" {
+				c.services = append(c.services, attr.GetValue().GetStringValue())
+			}
+		}
+		for _, ils := range rs.GetInstrumentationLibrarySpans() {
+			for _, s := range ils.GetSpans() {
+				c.spans = append(c.spans, s.GetName())
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	resp, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	_, _ = w.Write(resp)
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tp, err := NewTracerProvider(ctx, u.Host, "my-el")
+	if err != nil {
+		t.Fatalf("NewTracerProvider() unexpected error: %v", err)
+	}
+	_, span := tp.Tracer(tracerName).Start(ctx, handleEventSpanName)
+	span.End()
+	if err := tp.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() unexpected error: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if diff := cmp.Diff([]string{handleEventSpanName}, c.spans); diff != "" {
+		t.Errorf("collector received spans -want,+got: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"my-el"}, c.services); diff != "" {
+		t.Errorf("collector received traces from services -want,+got: %s", diff)
+	}
+}
@@ -20,10 +20,77 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"strings"
 )
 
+// EnforcePayloadPolicy rejects requests whose body is larger than MaxBodySize with a
+// 413 status, and requests whose content type is not one of AllowedContentTypes with
+// a 415 status. Other requests are passed on to eventHandler.
+func (r Sink) EnforcePayloadPolicy(eventHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		if len(r.AllowedContentTypes) > 0 {
+			contentType := request.Header.Get("Content-Type")
+			if !isAllowedContentType(contentType, r.AllowedContentTypes) {
+				r.rejectPayload(response, http.StatusUnsupportedMediaType,
+					fmt.Sprintf("content type %q is not allowed, must be one of: %s", contentType, strings.Join(r.AllowedContentTypes, ", ")))
+				return
+			}
+		}
+		if r.MaxBodySize > 0 {
+			tooLarge := fmt.Sprintf("request body is larger than %d bytes", r.MaxBodySize)
+			if request.ContentLength > r.MaxBodySize {
+				r.rejectPayload(response, http.StatusRequestEntityTooLarge, tooLarge)
+				return
+			}
+			// The Content-Length header is not set for chunked requests, so the body
+			// is read up to one byte past the limit to find out if it is too large.
+			payload, err := ioutil.ReadAll(io.LimitReader(request.Body, r.MaxBodySize+1))
+			if err != nil {
+				r.recordCountMetrics(failTag)
+				r.Logger.Errorf("Error reading event body: %s", err)
+				response.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if int64(len(payload)) > r.MaxBodySize {
+				r.rejectPayload(response, http.StatusRequestEntityTooLarge, tooLarge)
+				return
+			}
+			request.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+		}
+		eventHandler.ServeHTTP(response, request)
+	})
+}
+
+// rejectPayload responds to a request that violates the payload policy of the EventListener.
+func (r Sink) rejectPayload(response http.ResponseWriter, status int, errMsg string) {
+	r.recordCountMetrics(failTag)
+	r.Logger.Warnf("rejecting event: %s", errMsg)
+	r.writeResponse(response, status, Response{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		ErrorMessage:  errMsg,
+	}, r.Logger)
+}
+
+// isAllowedContentType returns true if the media type of contentType is one of allowed.
+// Parameters such as the charset are ignored.
+func isAllowedContentType(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(mediaType, a) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r Sink) IsValidPayload(eventHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
 		payload, err := ioutil.ReadAll(request.Body)
@@ -34,7 +101,8 @@ func (r Sink) IsValidPayload(eventHandler http.Handler) http.Handler {
 			response.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		if r.PayloadValidation {
+		// The data of CloudEvents does not have to be JSON, and is converted by HandleEvent.
+		if r.PayloadValidation && !isCloudEvent(request) {
 			var event map[string]interface{}
 			if err := json.Unmarshal([]byte(payload), &event); err != nil {
 				errMsg := fmt.Sprintf("Invalid event body format format: %s", err)
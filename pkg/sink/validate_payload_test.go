@@ -19,10 +19,13 @@ package sink
 import (
 	"bytes"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	"github.com/tektoncd/triggers/test"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,3 +159,116 @@ func TestSink_IsValidPayload_PayloadValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestSink_EnforcePayloadPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		maxBodySize         int64
+		allowedContentTypes []string
+		contentType         string
+		eventBody           []byte
+		chunked             bool
+		wantStatusCode      int
+		wantErrorMessage    string
+	}{{
+		name:           "no policy",
+		contentType:    "text/plain",
+		eventBody:      []byte(`hello`),
+		wantStatusCode: http.StatusOK,
+	}, {
+		name:           "body within limit",
+		maxBodySize:    16,
+		contentType:    "application/json",
+		eventBody:      []byte(`{"foo": "bar"}`),
+		wantStatusCode: http.StatusOK,
+	}, {
+		name:             "body over limit",
+		maxBodySize:      8,
+		contentType:      "application/json",
+		eventBody:        []byte(`{"foo": "bar"}`),
+		wantStatusCode:   http.StatusRequestEntityTooLarge,
+		wantErrorMessage: "request body is larger than 8 bytes",
+	}, {
+		name:             "chunked body over limit",
+		maxBodySize:      8,
+		contentType:      "application/json",
+		eventBody:        []byte(`{"foo": "bar"}`),
+		chunked:          true,
+		wantStatusCode:   http.StatusRequestEntityTooLarge,
+		wantErrorMessage: "request body is larger than 8 bytes",
+	}, {
+		name:                "allowed content type with parameters",
+		allowedContentTypes: []string{"application/json"},
+		contentType:         "Application/JSON; charset=utf-8",
+		eventBody:           []byte(`{}`),
+		wantStatusCode:      http.StatusOK,
+	}, {
+		name:                "content type not allowed",
+		allowedContentTypes: []string{"application/json", "application/cloudevents+json"},
+		contentType:         "text/xml",
+		eventBody:           []byte(`<test>xml</test>`),
+		wantStatusCode:      http.StatusUnsupportedMediaType,
+		wantErrorMessage:    `content type "text/xml" is not allowed, must be one of: application/json, application/cloudevents+json`,
+	}, {
+		name:                "missing content type",
+		allowedContentTypes: []string{"application/json"},
+		eventBody:           []byte(`{}`),
+		wantStatusCode:      http.StatusUnsupportedMediaType,
+		wantErrorMessage:    `content type "" is not allowed, must be one of: application/json`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, _ := getSinkAssets(t, test.Resources{}, "test-el", nil)
+			sink.MaxBodySize = tc.maxBodySize
+			sink.AllowedContentTypes = tc.allowedContentTypes
+
+			var gotBody []byte
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotBody, _ = ioutil.ReadAll(r.Body)
+			})
+			ts := httptest.NewServer(sink.EnforcePayloadPolicy(handler))
+			defer ts.Close()
+
+			var body io.Reader = bytes.NewReader(tc.eventBody)
+			if tc.chunked {
+				// Hide the length of the body so that the request is sent without Content-Length.
+				body = ioutil.NopCloser(body)
+			}
+			req, err := http.NewRequest(http.MethodPost, ts.URL, body)
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error making request to eventListener: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantStatusCode {
+				t.Fatalf("Status code mismatch: got %d, want %d", resp.StatusCode, tc.wantStatusCode)
+			}
+			if tc.wantStatusCode == http.StatusOK {
+				if !bytes.Equal(gotBody, tc.eventBody) {
+					t.Errorf("handler got body %q, want %q", gotBody, tc.eventBody)
+				}
+				return
+			}
+			if gotBody != nil {
+				t.Errorf("rejected request was passed on to the handler")
+			}
+			var got Response
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			want := Response{
+				EventListener: "test-el",
+				Namespace:     namespace,
+				ErrorMessage:  tc.wantErrorMessage,
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("response (-want, +got): %s", diff)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/textproto"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,23 +29,39 @@ import (
 )
 
 const (
+	// CloudEventHeaderPrefix is the prefix of the headers that carry the attributes
+	// of a CloudEvent in binary content mode.
+	CloudEventHeaderPrefix = "Ce-"
+
 	// OldEscapeAnnotation is used to determine whether or not a TriggerTemplate
 	// should retain the old "replace quotes with backslack quote" behaviour
 	// when templating in params.
 	//
 	// This can be removed when this functionality is no-longer needed.
 	OldEscapeAnnotation = "triggers.tekton.dev/old-escape-quotes"
+
+	// AtomicAnnotation is used to determine whether or not the resources that a
+	// TriggerTemplate created for an event are deleted when the creation of its
+	// other resources fails.
+	AtomicAnnotation = "triggers.tekton.dev/atomic"
 )
 
+// EventContext holds the values about an event that are not part of its request,
+// which bindings access as $(context.<name>).
+type EventContext struct {
+	// ClientIP is the IP of the client that sent the event.
+	ClientIP string `json:"clientIP"`
+}
+
 // ResolveParams takes given triggerbindings and produces the resulting
 // resource params.
-func ResolveParams(rt ResolvedTrigger, body []byte, header http.Header, extensions map[string]interface{}) ([]triggersv1.Param, error) {
+func ResolveParams(rt ResolvedTrigger, body []byte, header http.Header, extensions map[string]interface{}, eventContext EventContext) ([]triggersv1.Param, error) {
 	var ttParams []triggersv1.ParamSpec
 	if rt.TriggerTemplate != nil {
 		ttParams = rt.TriggerTemplate.Spec.Params
 	}
 
-	out, err := applyEventValuesToParams(rt.BindingParams, body, header, extensions, ttParams)
+	out, err := applyEventValuesToParams(rt.BindingParams, body, header, extensions, eventContext, ttParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to ApplyEventValuesToParams: %w", err)
 	}
@@ -53,6 +70,8 @@ func ResolveParams(rt ResolvedTrigger, body []byte, header http.Header, extensio
 }
 
 // ResolveResources resolves a templated resource by replacing params with their values.
+// The resources are returned in the order they are created in, and their references to
+// each other refer to the positions of the referenced resources in that order.
 func ResolveResources(template *triggersv1.TriggerTemplate, params []triggersv1.Param) []json.RawMessage {
 	resources := make([]json.RawMessage, len(template.Spec.ResourceTemplates))
 	uid := UUID()
@@ -63,7 +82,31 @@ func ResolveResources(template *triggersv1.TriggerTemplate, params []triggersv1.
 		resources[i] = applyParamsToResourceTemplate(params, template.Spec.ResourceTemplates[i].RawExtension.Raw, oldEscape)
 		resources[i] = applyUIDToResourceTemplate(resources[i], uid)
 	}
-	return resources
+	return orderResources(resources)
+}
+
+// ResolveValue replaces the $() expressions in value with values from the event
+// body, headers, and extensions, in the same way as a TriggerBinding param value.
+func ResolveValue(value string, body []byte, header http.Header, extensions map[string]interface{}) (string, error) {
+	params, err := applyEventValuesToParams([]triggersv1.Param{{Name: "value", Value: value}}, body, header, extensions, EventContext{}, nil)
+	if err != nil {
+		return "", err
+	}
+	return params[0].Value, nil
+}
+
+// CloudEventAttributes returns the attributes of the CloudEvent carried by the headers
+// of a request in binary content mode, keyed by their lowercase names. It returns an
+// empty map if the headers do not carry a CloudEvent.
+func CloudEventAttributes(header http.Header) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	for k, v := range header {
+		k = textproto.CanonicalMIMEHeaderKey(k)
+		if len(k) > len(CloudEventHeaderPrefix) && strings.HasPrefix(k, CloudEventHeaderPrefix) {
+			attrs[strings.ToLower(k[len(CloudEventHeaderPrefix):])] = strings.Join(v, ",")
+		}
+	}
+	return attrs
 }
 
 // event represents a HTTP event that Triggers processes
@@ -71,10 +114,12 @@ type event struct {
 	Header     map[string]string      `json:"header"`
 	Body       interface{}            `json:"body"`
 	Extensions map[string]interface{} `json:"extensions"`
+	CE         map[string]interface{} `json:"ce"`
+	Context    EventContext           `json:"context"`
 }
 
 // newEvent returns a new Event from HTTP headers and body
-func newEvent(body []byte, headers http.Header, extensions map[string]interface{}) (*event, error) {
+func newEvent(body []byte, headers http.Header, extensions map[string]interface{}, eventContext EventContext) (*event, error) {
 	var data interface{}
 	if len(body) > 0 {
 		if err := json.Unmarshal(body, &data); err != nil {
@@ -90,14 +135,16 @@ func newEvent(body []byte, headers http.Header, extensions map[string]interface{
 		Header:     joinedHeaders,
 		Body:       data,
 		Extensions: extensions,
+		CE:         CloudEventAttributes(headers),
+		Context:    eventContext,
 	}, nil
 }
 
 // applyEventValuesToParams returns a slice of Params with the JSONPath variables replaced
-// with values from the event body, headers, and extensions.
+// with values from the event body, headers, extensions, and context.
 func applyEventValuesToParams(params []triggersv1.Param, body []byte, header http.Header, extensions map[string]interface{},
-	defaults []triggersv1.ParamSpec) ([]triggersv1.Param, error) {
-	event, err := newEvent(body, header, extensions)
+	eventContext EventContext, defaults []triggersv1.ParamSpec) ([]triggersv1.Param, error) {
+	event, err := newEvent(body, header, extensions, eventContext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal event: %w", err)
 	}
@@ -127,7 +127,7 @@ func TestApplyEventValuesMergeInDefaultParams(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyEventValuesToParams(tt.args.params, nil, nil, nil, tt.args.paramSpecs)
+			got, err := applyEventValuesToParams(tt.args.params, nil, nil, nil, EventContext{}, tt.args.paramSpecs)
 			if err != nil {
 				t.Errorf("applyEventValuesToParams(): unexpected error: %s", err.Error())
 			}
@@ -296,11 +296,35 @@ func TestApplyEventValuesToParams(t *testing.T) {
 		},
 		params: []triggersv1.Param{{Name: "a", Value: "$(extensions.foo)"}},
 		want:   []triggersv1.Param{{Name: "a", Value: `[{"a":"1"},{"b":"2"}]`}},
+	}, {
+		name: "CloudEvent attributes",
+		body: []byte(`{}`),
+		header: map[string][]string{
+			"Ce-Specversion": {"1.0"},
+			"Ce-Type":        {"com.example.push"},
+			"ce-source":      {"/repos/example"},
+			"Ce-Id":          {"abc-123"},
+		},
+		params: []triggersv1.Param{
+			{Name: "type", Value: "$(ce.type)"},
+			{Name: "source", Value: "$(ce.source)"},
+			{Name: "id", Value: "$(ce.id)"},
+		},
+		want: []triggersv1.Param{
+			{Name: "type", Value: "com.example.push"},
+			{Name: "source", Value: "/repos/example"},
+			{Name: "id", Value: "abc-123"},
+		},
+	}, {
+		name:   "body that is not a JSON object",
+		body:   []byte(`"hello"`),
+		params: []triggersv1.Param{{Name: "a", Value: "$(body)"}},
+		want:   []triggersv1.Param{{Name: "a", Value: "hello"}},
 	}}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyEventValuesToParams(tt.params, tt.body, tt.header, tt.extensions, nil)
+			got, err := applyEventValuesToParams(tt.params, tt.body, tt.header, tt.extensions, EventContext{}, nil)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -341,7 +365,7 @@ func TestApplyEventValuesToParams_Error(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyEventValuesToParams(tt.params, tt.body, tt.header, tt.extensions, nil)
+			got, err := applyEventValuesToParams(tt.params, tt.body, tt.header, tt.extensions, EventContext{}, nil)
 			if err == nil {
 				t.Errorf("did not get expected error - got: %v", got)
 			}
@@ -463,7 +487,7 @@ func TestResolveParams(t *testing.T) {
 				BindingParams:   tt.bindingParams,
 				TriggerTemplate: tt.template,
 			}
-			params, err := ResolveParams(rt, tt.body, map[string][]string{}, tt.extensions)
+			params, err := ResolveParams(rt, tt.body, map[string][]string{}, tt.extensions, EventContext{})
 			if err != nil {
 				t.Fatalf("ResolveParams() returned unexpected error: %s", err)
 			}
@@ -495,7 +519,7 @@ func TestResolveParams_Error(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			params, err := ResolveParams(ResolvedTrigger{BindingParams: tt.bindingParams}, tt.body, map[string][]string{}, tt.extensions)
+			params, err := ResolveParams(ResolvedTrigger{BindingParams: tt.bindingParams}, tt.body, map[string][]string{}, tt.extensions, EventContext{})
 			if err == nil {
 				t.Errorf("did not get expected error - got: %v", params)
 			}
@@ -503,6 +527,54 @@ func TestResolveParams_Error(t *testing.T) {
 	}
 }
 
+func TestResolveParams_EventContext(t *testing.T) {
+	rt := ResolvedTrigger{BindingParams: []triggersv1.Param{{Name: "sender", Value: "$(context.clientIP)"}}}
+	params, err := ResolveParams(rt, nil, http.Header{}, nil, EventContext{ClientIP: "192.30.252.1"})
+	if err != nil {
+		t.Fatalf("ResolveParams() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]triggersv1.Param{{Name: "sender", Value: "192.30.252.1"}}, params); diff != "" {
+		t.Errorf("ResolveParams() -want,+got: %s", diff)
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	header := http.Header{"X-Github-Delivery": []string{"72d3162e"}}
+	body := json.RawMessage(`{"repository": {"id": 1296269}}`)
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{{
+		name:  "header",
+		value: "$(header.X-GitHub-Delivery)",
+		want:  "72d3162e",
+	}, {
+		name:  "body and header",
+		value: "$(body.repository.id)-$(header.X-GitHub-Delivery)",
+		want:  "1296269-72d3162e",
+	}, {
+		name:  "no expression",
+		value: "static",
+		want:  "static",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveValue(tt.value, body, header, nil)
+			if err != nil {
+				t.Fatalf("ResolveValue() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got, err := ResolveValue("$(header.X-Gitlab-Event-UUID)", body, header, nil); err == nil {
+		t.Errorf("ResolveValue() expected error for missing header, got %q", got)
+	}
+}
+
 func addOldEscape(t *triggersv1.TriggerTemplate) *triggersv1.TriggerTemplate {
 	t.Annotations = map[string]string{
 		OldEscapeAnnotation: "yes",
@@ -626,6 +698,28 @@ func TestResolveResources(t *testing.T) {
 			json.RawMessage(`{"rt1": "31313131-3131-4131-b131-313131313131"}`),
 			json.RawMessage(`{"rt2": "31313131-3131-4131-b131-313131313131"}`),
 		},
+	}, {
+		name: "resources are ordered by their references",
+		template: &triggersv1.TriggerTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tt",
+				Namespace: ns,
+			},
+			Spec: triggersv1.TriggerTemplateSpec{
+				ResourceTemplates: []triggersv1.TriggerResourceTemplate{{
+					RawExtension: runtime.RawExtension{Raw: []byte(`{"pvc": "$(resources.run.metadata.uid)"}`)},
+				}, {
+					RawExtension: runtime.RawExtension{Raw: []byte(`{"cm": "$(resources.0.metadata.name)"}`)},
+				}, {
+					RawExtension: runtime.RawExtension{Raw: []byte(`{"metadata": {"annotations": {"triggers.tekton.dev/resource-name": "run"}}}`)},
+				}},
+			},
+		},
+		want: []json.RawMessage{
+			json.RawMessage(`{"metadata": {"annotations": {"triggers.tekton.dev/resource-name": "run"}}}`),
+			json.RawMessage(`{"pvc": "$(resources.0.metadata.uid)"}`),
+			json.RawMessage(`{"cm": "$(resources.1.metadata.name)"}`),
+		},
 	}}
 
 	for _, tt := range tests {
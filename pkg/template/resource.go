@@ -20,11 +20,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // uidMatch determines the uid variable within the resource template
@@ -170,3 +172,58 @@ func mergeBindingParams(bindings []*triggersv1.TriggerBinding, clusterbindings [
 	}
 	return params, nil
 }
+
+// orderResources sorts resources in the order they are created in, and rewrites their
+// references to each other to the positions of the referenced resources in that order.
+// Resources that cannot be ordered are returned as they are, and their references fail
+// to be resolved when they are created.
+func orderResources(resources []json.RawMessage) []json.RawMessage {
+	order, refs, err := triggersv1.ResourceTemplateOrder(resources)
+	if err != nil {
+		return resources
+	}
+	position := make([]int, len(order))
+	for pos, i := range order {
+		position[i] = pos
+	}
+	ordered := make([]json.RawMessage, len(order))
+	for pos, i := range order {
+		ordered[pos] = triggersv1.ResourceReferenceRegexp.ReplaceAllFunc(resources[i], func(ref []byte) []byte {
+			m := triggersv1.ResourceReferenceRegexp.FindSubmatch(ref)
+			return []byte(fmt.Sprintf("$(resources.%d.%s)", position[refs[string(m[1])]], m[2]))
+		})
+	}
+	return ordered
+}
+
+// ResolveResourceReferences replaces the references of the resource rt to the fields of
+// the resources created before it, $(resources.INDEX.PATH), with the values of the fields
+// of created[INDEX].
+func ResolveResourceReferences(rt json.RawMessage, created []*unstructured.Unstructured) (json.RawMessage, error) {
+	var err error
+	resolved := triggersv1.ResourceReferenceRegexp.ReplaceAllFunc(rt, func(ref []byte) []byte {
+		if err != nil {
+			return ref
+		}
+		m := triggersv1.ResourceReferenceRegexp.FindSubmatch(ref)
+		i, convErr := strconv.Atoi(string(m[1]))
+		if convErr != nil || i >= len(created) {
+			err = fmt.Errorf("%s does not refer to a resource created before", ref)
+			return ref
+		}
+		value, found, _ := unstructured.NestedFieldNoCopy(created[i].Object, strings.Split(string(m[2]), ".")...)
+		switch value.(type) {
+		case string, bool, int64, float64:
+		default:
+			found = false
+		}
+		if !found {
+			err = fmt.Errorf("%s does not refer to a string, number or boolean field of %s %s", ref, created[i].GetKind(), created[i].GetName())
+			return ref
+		}
+		// The value is embedded in a JSON string.
+		b, _ := json.Marshal(fmt.Sprint(value))
+		return b[1 : len(b)-1]
+	})
+	return resolved, err
+}
@@ -27,6 +27,7 @@ import (
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
 	"github.com/tektoncd/triggers/test"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"knative.dev/pkg/ptr"
 )
 
@@ -885,3 +886,61 @@ func TestMergeBindingParams(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveResourceReferences(t *testing.T) {
+	created := []*unstructured.Unstructured{{
+		Object: map[string]interface{}{
+			"apiVersion": "tekton.dev/v1beta1",
+			"kind":       "PipelineRun",
+			"metadata": map[string]interface{}{
+				"name":       "run-abcde",
+				"uid":        "1234",
+				"generation": int64(1),
+			},
+			"spec": map[string]interface{}{
+				"params": []interface{}{},
+			},
+		},
+	}}
+	tests := []struct {
+		name    string
+		rt      string
+		want    string
+		wantErr bool
+	}{{
+		name: "no references",
+		rt:   `{"name": "$(tt.params.name)"}`,
+		want: `{"name": "$(tt.params.name)"}`,
+	}, {
+		name: "string and number fields",
+		rt:   `{"ownerReferences": [{"name": "$(resources.0.metadata.name)", "uid": "$(resources.0.metadata.uid)"}], "gen": "$(resources.0.metadata.generation)"}`,
+		want: `{"ownerReferences": [{"name": "run-abcde", "uid": "1234"}], "gen": "1"}`,
+	}, {
+		name:    "resource not created before",
+		rt:      `{"name": "$(resources.1.metadata.name)"}`,
+		wantErr: true,
+	}, {
+		name:    "unresolved name",
+		rt:      `{"name": "$(resources.run.metadata.name)"}`,
+		wantErr: true,
+	}, {
+		name:    "missing field",
+		rt:      `{"name": "$(resources.0.metadata.namespace)"}`,
+		wantErr: true,
+	}, {
+		name:    "field that is not a string",
+		rt:      `{"params": "$(resources.0.spec.params)"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveResourceReferences(json.RawMessage(tt.rt), created)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveResourceReferences() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.want {
+				t.Errorf("ResolveResourceReferences() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -61,6 +61,9 @@ const (
 	triggerLabel  = triggers.GroupName + triggers.TriggerLabelKey
 	eventIDLabel  = triggers.GroupName + triggers.EventIDLabelKey
 
+	triggerNamespaceLabel = triggers.GroupName + triggers.TriggerNamespaceLabelKey
+	createdByEventLabel   = triggers.GroupName + triggers.CreatedByEventLabelKey
+
 	examplePRJsonFilename = "pr.json"
 )
 
@@ -394,9 +397,11 @@ func TestEventListenerCreate(t *testing.T) {
 			Name:      "pr1",
 			Namespace: namespace,
 			Labels: map[string]string{
-				resourceLabel: "my-eventlistener",
-				triggerLabel:  el.Spec.Triggers[0].Name,
-				"edited":      "edited",
+				resourceLabel:         "my-eventlistener",
+				triggerLabel:          el.Spec.Triggers[0].Name,
+				triggerNamespaceLabel: namespace,
+				createdByEventLabel:   "true",
+				"edited":              "edited",
 			},
 		},
 		Spec: v1alpha1.PipelineResourceSpec{
@@ -412,9 +417,11 @@ func TestEventListenerCreate(t *testing.T) {
 			Name:      "pr2",
 			Namespace: namespace,
 			Labels: map[string]string{
-				resourceLabel: "my-eventlistener",
-				triggerLabel:  el.Spec.Triggers[0].Name,
-				"open":        "defaultvalue",
+				resourceLabel:         "my-eventlistener",
+				triggerLabel:          el.Spec.Triggers[0].Name,
+				triggerNamespaceLabel: namespace,
+				createdByEventLabel:   "true",
+				"open":                "defaultvalue",
 			},
 		},
 		Spec: v1alpha1.PipelineResourceSpec{